@@ -0,0 +1,31 @@
+package provider
+
+import (
+	azureutil "github.com/daytonaio/daytona-provider-azure/pkg/provider/util"
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+)
+
+// getClientFactory returns the cached ARM client factory for the subscription and
+// identity in the target options, creating it on first use.
+func (a *AzureProvider) getClientFactory(targetOptions *types.TargetOptions) (*azureutil.ClientFactory, error) {
+	key := azureutil.GetClientFactoryKey(targetOptions)
+
+	a.clientFactoriesMutex.Lock()
+	defer a.clientFactoriesMutex.Unlock()
+
+	if clientFactory, ok := a.clientFactories[key]; ok {
+		return clientFactory, nil
+	}
+
+	clientFactory, err := azureutil.NewClientFactory(targetOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.clientFactories == nil {
+		a.clientFactories = make(map[string]*azureutil.ClientFactory)
+	}
+	a.clientFactories[key] = clientFactory
+
+	return clientFactory, nil
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+)
+
+// getCustomPresets reads the admin-defined presets from presets.json under BasePath.
+// A missing file means there are no custom presets.
+func (a *AzureProvider) getCustomPresets() ([]types.Preset, error) {
+	if a.BasePath == nil {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(filepath.Join(*a.BasePath, "presets.json"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var presets []types.Preset
+	err = json.Unmarshal(content, &presets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse presets.json: %w", err)
+	}
+
+	for _, preset := range presets {
+		if preset.Name == "" {
+			return nil, errors.New("failed to parse presets.json: preset name is required")
+		}
+	}
+
+	return presets, nil
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"path"
+	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/daytonaio/daytona-provider-azure/internal"
@@ -37,6 +39,9 @@ type AzureProvider struct {
 	WorkspaceLogsDir   *string
 	TargetLogsDir      *string
 	tsnetConn          *tsnet.Server
+
+	clientFactories      map[string]*azureutil.ClientFactory
+	clientFactoriesMutex sync.Mutex
 }
 
 func (a *AzureProvider) Initialize(req provider.InitializeProviderRequest) (*util.Empty, error) {
@@ -62,12 +67,22 @@ func (a *AzureProvider) GetInfo() (models.ProviderInfo, error) {
 		Label:                &label,
 		Name:                 "azure-provider",
 		Version:              internal.Version,
-		TargetConfigManifest: *types.GetTargetConfigManifest(),
+		TargetConfigManifest: *types.GetTargetConfigManifestWithSuggestions(a.getSuggestions()),
 	}, nil
 }
 
 func (a *AzureProvider) GetPresetTargetConfigs() (*[]provider.TargetConfig, error) {
-	return new([]provider.TargetConfig), nil
+	customPresets, err := a.getCustomPresets()
+	if err != nil {
+		return nil, err
+	}
+
+	targetConfigs, err := types.GetPresetTargetConfigs(customPresets)
+	if err != nil {
+		return nil, err
+	}
+
+	return &targetConfigs, nil
 }
 
 func (a *AzureProvider) CreateTarget(targetReq *provider.TargetRequest) (*util.Empty, error) {
@@ -83,8 +98,14 @@ func (a *AzureProvider) CreateTarget(targetReq *provider.TargetRequest) (*util.E
 		return nil, err
 	}
 
+	clients, err := a.getClientFactory(targetOptions)
+	if err != nil {
+		logWriter.Write([]byte("Failed to create Azure clients: " + err.Error() + "\n"))
+		return nil, err
+	}
+
 	initScript := fmt.Sprintf(`curl -sfL -H "Authorization: Bearer %s" %s | bash`, targetReq.Target.ApiKey, *a.DaytonaDownloadUrl)
-	err = azureutil.CreateTarget(targetReq.Target, targetOptions, initScript, logWriter)
+	err = azureutil.CreateTarget(targetReq.Target, targetOptions, clients, initScript, a.getSSHKeyDir(targetReq.Target.Id), logWriter)
 	if err != nil {
 		logWriter.Write([]byte("Failed to create target: " + err.Error() + "\n"))
 		return nil, err
@@ -122,20 +143,27 @@ func (a *AzureProvider) StartTarget(targetReq *provider.TargetRequest) (*util.Em
 	logWriter, cleanupFunc := a.getTargetLogWriter(targetReq.Target.Id, targetReq.Target.Name)
 	defer cleanupFunc()
 
-	err := a.waitForDial(targetReq.Target.Id, 10*time.Minute)
+	targetOptions, err := types.ParseTargetOptions(targetReq.Target.TargetConfig.Options)
 	if err != nil {
-		logWriter.Write([]byte("Failed to dial: " + err.Error() + "\n"))
+		logWriter.Write([]byte("Failed to parse target options: " + err.Error() + "\n"))
 		return nil, err
 	}
 
-	targetOptions, err := types.ParseTargetOptions(targetReq.Target.TargetConfig.Options)
+	clients, err := a.getClientFactory(targetOptions)
 	if err != nil {
-		logWriter.Write([]byte("Failed to parse target options: " + err.Error() + "\n"))
+		logWriter.Write([]byte("Failed to create Azure clients: " + err.Error() + "\n"))
 		return nil, err
 	}
 
-	err = azureutil.StartTarget(targetReq.Target, targetOptions)
+	err = azureutil.StartTarget(targetReq.Target, targetOptions, clients, logWriter)
 	if err != nil {
+		logWriter.Write([]byte("Failed to start target: " + err.Error() + "\n"))
+		return nil, err
+	}
+
+	err = a.waitForDial(targetReq.Target.Id, 10*time.Minute)
+	if err != nil {
+		logWriter.Write([]byte("Failed to dial: " + err.Error() + "\n"))
 		return nil, err
 	}
 
@@ -152,7 +180,13 @@ func (a *AzureProvider) StopTarget(targetReq *provider.TargetRequest) (*util.Emp
 		return nil, err
 	}
 
-	return new(util.Empty), azureutil.StopTarget(targetReq.Target, targetOptions)
+	clients, err := a.getClientFactory(targetOptions)
+	if err != nil {
+		logWriter.Write([]byte("Failed to create Azure clients: " + err.Error() + "\n"))
+		return nil, err
+	}
+
+	return new(util.Empty), azureutil.StopTarget(targetReq.Target, targetOptions, clients)
 }
 
 func (a *AzureProvider) DestroyTarget(targetReq *provider.TargetRequest) (*util.Empty, error) {
@@ -165,7 +199,18 @@ func (a *AzureProvider) DestroyTarget(targetReq *provider.TargetRequest) (*util.
 		return nil, err
 	}
 
-	return new(util.Empty), azureutil.DeleteTarget(targetReq.Target, targetOptions)
+	clients, err := a.getClientFactory(targetOptions)
+	if err != nil {
+		logWriter.Write([]byte("Failed to create Azure clients: " + err.Error() + "\n"))
+		return nil, err
+	}
+
+	err = azureutil.DeleteTarget(targetReq.Target, targetOptions, clients)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(util.Empty), azureutil.DeleteSSHKey(a.getSSHKeyDir(targetReq.Target.Id))
 }
 
 func (a *AzureProvider) GetTargetProviderMetadata(targetReq *provider.TargetRequest) (string, error) {
@@ -178,13 +223,17 @@ func (a *AzureProvider) GetTargetProviderMetadata(targetReq *provider.TargetRequ
 		return "", err
 	}
 
-	vm, err := azureutil.GetVirtualMachine(targetReq.Target, targetOptions)
+	clients, err := a.getClientFactory(targetOptions)
 	if err != nil {
-		logWriter.Write([]byte("Failed to get machine: " + err.Error() + "\n"))
+		logWriter.Write([]byte("Failed to create Azure clients: " + err.Error() + "\n"))
 		return "", err
 	}
 
-	metadata := types.ToTargetMetadata(vm)
+	metadata, err := azureutil.GetTargetMetadata(targetReq.Target, targetOptions, clients)
+	if err != nil {
+		logWriter.Write([]byte("Failed to get machine: " + err.Error() + "\n"))
+		return "", err
+	}
 
 	jsonMetadata, err := json.Marshal(metadata)
 	if err != nil {
@@ -195,7 +244,27 @@ func (a *AzureProvider) GetTargetProviderMetadata(targetReq *provider.TargetRequ
 }
 
 func (a *AzureProvider) CheckRequirements() (*[]provider.RequirementStatus, error) {
-	results := []provider.RequirementStatus{}
+	targetOptions, err := types.ParseTargetOptions("{}")
+	if err != nil {
+		results := []provider.RequirementStatus{
+			{Name: "Azure credentials", Met: false, Reason: err.Error()},
+		}
+		return &results, nil
+	}
+
+	manifest := *types.GetTargetConfigManifest()
+	targetOptions.Region = manifest["Region"].DefaultValue
+	targetOptions.VMSize = manifest["VM Size"].DefaultValue
+
+	clients, err := a.getClientFactory(targetOptions)
+	if err != nil {
+		results := []provider.RequirementStatus{
+			{Name: "Azure credentials", Met: false, Reason: err.Error()},
+		}
+		return &results, nil
+	}
+
+	results := azureutil.CheckRequirements(targetOptions, clients)
 	return &results, nil
 }
 
@@ -356,6 +425,12 @@ func (a *AzureProvider) getWorkspaceLogWriter(workspaceId, workspaceName string)
 	return logWriter, cleanupFunc
 }
 
+// getSSHKeyDir returns the directory under BasePath that holds the SSH keypair of the
+// target.
+func (a *AzureProvider) getSSHKeyDir(targetId string) string {
+	return filepath.Join(*a.BasePath, "ssh-keys", targetId)
+}
+
 func getTargetDir(targetId string) string {
 	return fmt.Sprintf("/home/daytona/%s", targetId)
 }
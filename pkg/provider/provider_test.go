@@ -36,7 +36,12 @@ var (
 func TestCreateTarget(t *testing.T) {
 	_, _ = azureProvider.CreateTarget(targetReq)
 
-	_, err := azureutil.GetVirtualMachine(targetReq.Target, targetOptions)
+	clients, err := azureProvider.getClientFactory(targetOptions)
+	if err != nil {
+		t.Fatalf("Error creating Azure clients: %s", err)
+	}
+
+	_, err = azureutil.GetVirtualMachine(targetReq.Target, targetOptions, clients)
 	if err != nil {
 		t.Fatalf("Error getting machine: %s", err)
 	}
@@ -49,7 +54,12 @@ func TestDestroyTarget(t *testing.T) {
 	}
 	time.Sleep(3 * time.Second)
 
-	_, err = azureutil.GetVirtualMachine(targetReq.Target, targetOptions)
+	clients, err := azureProvider.getClientFactory(targetOptions)
+	if err != nil {
+		t.Fatalf("Error creating Azure clients: %s", err)
+	}
+
+	_, err = azureutil.GetVirtualMachine(targetReq.Target, targetOptions, clients)
 	if err == nil {
 		t.Fatalf("Error destroyed target still exists")
 	}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	azureutil "github.com/daytonaio/daytona-provider-azure/pkg/provider/util"
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	suggestionsCacheTTL      = 24 * time.Hour
+	suggestionsRetryInterval = time.Hour
+	suggestionsFetchTimeout  = 30 * time.Second
+)
+
+// suggestionsRefreshMutex ensures that only one refresh runs at a time.
+var suggestionsRefreshMutex sync.Mutex
+
+type suggestionsCache struct {
+	UpdatedAt   time.Time          `json:"updatedAt"`
+	FailedAt    time.Time          `json:"failedAt,omitempty"`
+	Suggestions *types.Suggestions `json:"suggestions,omitempty"`
+}
+
+// needsRefresh reports whether the cached suggestions are stale and the last failed
+// refresh, if any, is long enough ago to try again.
+func (c *suggestionsCache) needsRefresh() bool {
+	return time.Since(c.UpdatedAt) >= suggestionsCacheTTL && time.Since(c.FailedAt) >= suggestionsRetryInterval
+}
+
+// getSuggestions returns the target config suggestions for the subscription in the
+// AZURE_* environment variables. Suggestions are cached under BasePath and refreshed in
+// the background once the cache is older than suggestionsCacheTTL, so the cached or
+// built-in suggestions are returned immediately. Failed refreshes are retried after
+// suggestionsRetryInterval.
+func (a *AzureProvider) getSuggestions() *types.Suggestions {
+	if a.BasePath == nil {
+		return types.GetStaticSuggestions()
+	}
+
+	cachePath := filepath.Join(*a.BasePath, "suggestions.json")
+	cache, err := readSuggestionsCache(cachePath)
+	if err != nil {
+		cache = &suggestionsCache{}
+	}
+
+	if cache.needsRefresh() {
+		go a.refreshSuggestions(cachePath, cache)
+	}
+
+	if cache.Suggestions != nil {
+		return cache.Suggestions
+	}
+
+	return types.GetStaticSuggestions()
+}
+
+// refreshSuggestions fetches the suggestions and caches them, or records the failed
+// attempt so that it is not retried on every call.
+func (a *AzureProvider) refreshSuggestions(cachePath string, cache *suggestionsCache) {
+	if !suggestionsRefreshMutex.TryLock() {
+		return
+	}
+	defer suggestionsRefreshMutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), suggestionsFetchTimeout)
+	defer cancel()
+
+	suggestions, err := a.fetchSuggestions(ctx)
+	if err != nil {
+		log.Warnf("Failed to fetch suggestions: %s", err)
+		cache.FailedAt = time.Now()
+	} else {
+		cache = &suggestionsCache{
+			UpdatedAt:   time.Now(),
+			Suggestions: suggestions,
+		}
+	}
+
+	err = writeSuggestionsCache(cachePath, cache)
+	if err != nil {
+		log.Warnf("Failed to cache suggestions: %s", err)
+	}
+}
+
+func (a *AzureProvider) fetchSuggestions(ctx context.Context) (*types.Suggestions, error) {
+	targetOptions, err := types.ParseTargetOptions("{}")
+	if err != nil {
+		return nil, err
+	}
+
+	clients, err := a.getClientFactory(targetOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return azureutil.FetchSuggestions(ctx, types.DefaultRegion, clients)
+}
+
+func readSuggestionsCache(path string) (*suggestionsCache, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cache suggestionsCache
+	err = json.Unmarshal(content, &cache)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cache, nil
+}
+
+func writeSuggestionsCache(path string, cache *suggestionsCache) error {
+	content, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0644)
+}
@@ -0,0 +1,218 @@
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+)
+
+// ClientFactory holds the Azure Resource Manager clients for a single subscription
+// and identity. All clients share one credential, so access tokens and HTTP
+// pipelines are reused across operations.
+type ClientFactory struct {
+	subscriptionId string
+	cred           azcore.TokenCredential
+	tokenScope     string
+	options        *arm.ClientOptions
+
+	resourceGroups       *armresources.ResourceGroupsClient
+	providers            *armresources.ProvidersClient
+	virtualMachines      *armcompute.VirtualMachinesClient
+	disks                *armcompute.DisksClient
+	resourceSKUs         *armcompute.ResourceSKUsClient
+	virtualMachineImages *armcompute.VirtualMachineImagesClient
+	usage                *armcompute.UsageClient
+	virtualNetworks      *armnetwork.VirtualNetworksClient
+	subnets              *armnetwork.SubnetsClient
+	interfaces           *armnetwork.InterfacesClient
+	securityGroups       *armnetwork.SecurityGroupsClient
+	publicIPAddresses    *armnetwork.PublicIPAddressesClient
+	natGateways          *armnetwork.NatGatewaysClient
+	serviceTags          *armnetwork.ServiceTagsClient
+	privateDNSRecordSets *armprivatedns.RecordSetsClient
+}
+
+// NewClientFactory creates the credential and ARM clients for the given target options.
+func NewClientFactory(opts *types.TargetOptions) (*ClientFactory, error) {
+	cred, err := getClientCredentials(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudConfig, err := getCloudConfiguration(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	audience := cloudConfig.Services[cloud.ResourceManager].Audience
+	if audience == "" {
+		audience = "https://management.core.windows.net/"
+	}
+
+	factory := &ClientFactory{
+		subscriptionId: opts.SubscriptionId,
+		cred:           cred,
+		tokenScope:     strings.TrimSuffix(audience, "/") + "/.default",
+		options: &arm.ClientOptions{
+			ClientOptions: policy.ClientOptions{
+				Cloud: cloudConfig,
+			},
+		},
+	}
+
+	options := factory.options
+
+	factory.resourceGroups, err = armresources.NewResourceGroupsClient(opts.SubscriptionId, cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	factory.providers, err = armresources.NewProvidersClient(opts.SubscriptionId, cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	factory.virtualMachines, err = armcompute.NewVirtualMachinesClient(opts.SubscriptionId, cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	factory.disks, err = armcompute.NewDisksClient(opts.SubscriptionId, cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	factory.resourceSKUs, err = armcompute.NewResourceSKUsClient(opts.SubscriptionId, cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	factory.virtualMachineImages, err = armcompute.NewVirtualMachineImagesClient(opts.SubscriptionId, cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	factory.usage, err = armcompute.NewUsageClient(opts.SubscriptionId, cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	factory.virtualNetworks, err = armnetwork.NewVirtualNetworksClient(opts.SubscriptionId, cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	factory.subnets, err = armnetwork.NewSubnetsClient(opts.SubscriptionId, cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	factory.interfaces, err = armnetwork.NewInterfacesClient(opts.SubscriptionId, cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	factory.securityGroups, err = armnetwork.NewSecurityGroupsClient(opts.SubscriptionId, cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	factory.publicIPAddresses, err = armnetwork.NewPublicIPAddressesClient(opts.SubscriptionId, cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	factory.natGateways, err = armnetwork.NewNatGatewaysClient(opts.SubscriptionId, cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	factory.serviceTags, err = armnetwork.NewServiceTagsClient(opts.SubscriptionId, cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	factory.privateDNSRecordSets, err = armprivatedns.NewRecordSetsClient(opts.SubscriptionId, cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return factory, nil
+}
+
+// getVirtualNetworksClient returns a virtual networks client for the given subscription,
+// reusing the factory client when it is the factory subscription.
+func (f *ClientFactory) getVirtualNetworksClient(subscriptionId string) (*armnetwork.VirtualNetworksClient, error) {
+	if strings.EqualFold(subscriptionId, f.subscriptionId) {
+		return f.virtualNetworks, nil
+	}
+
+	return armnetwork.NewVirtualNetworksClient(subscriptionId, f.cred, f.options)
+}
+
+// getNatGatewaysClient returns a NAT gateways client for the given subscription.
+func (f *ClientFactory) getNatGatewaysClient(subscriptionId string) (*armnetwork.NatGatewaysClient, error) {
+	if strings.EqualFold(subscriptionId, f.subscriptionId) {
+		return f.natGateways, nil
+	}
+
+	return armnetwork.NewNatGatewaysClient(subscriptionId, f.cred, f.options)
+}
+
+// getPublicIPAddressesClient returns a public IP addresses client for the given subscription.
+func (f *ClientFactory) getPublicIPAddressesClient(subscriptionId string) (*armnetwork.PublicIPAddressesClient, error) {
+	if strings.EqualFold(subscriptionId, f.subscriptionId) {
+		return f.publicIPAddresses, nil
+	}
+
+	return armnetwork.NewPublicIPAddressesClient(subscriptionId, f.cred, f.options)
+}
+
+// getPrivateDNSRecordSetsClient returns a Private DNS record sets client for the given subscription.
+func (f *ClientFactory) getPrivateDNSRecordSetsClient(subscriptionId string) (*armprivatedns.RecordSetsClient, error) {
+	if strings.EqualFold(subscriptionId, f.subscriptionId) {
+		return f.privateDNSRecordSets, nil
+	}
+
+	return armprivatedns.NewRecordSetsClient(subscriptionId, f.cred, f.options)
+}
+
+// GetClientFactoryKey returns a key that identifies the subscription and identity
+// described by the target options. Secrets are hashed so the key can be kept in memory
+// without holding them in plain text. The content of a certificate file is part of the
+// key, so a certificate rotated in place gets a new client factory.
+func GetClientFactoryKey(opts *types.TargetOptions) string {
+	var certData []byte
+	if opts.AuthMethod == types.AuthMethodClientCertificate {
+		// A certificate that cannot be read fails when the client factory is created
+		certData, _ = loadClientCertificate(opts.ClientCertificate)
+	}
+
+	identity := strings.Join([]string{
+		opts.Cloud,
+		opts.ARMEndpoint,
+		opts.AuthorityHost,
+		opts.ARMAudience,
+		opts.AuthMethod,
+		opts.TenantId,
+		opts.ClientId,
+		opts.ClientSecret,
+		opts.ClientCertificate,
+		opts.ClientCertificatePassword,
+		opts.FederatedTokenFile,
+		string(certData),
+	}, "\x00")
+
+	hash := sha256.Sum256([]byte(identity))
+	return opts.SubscriptionId + "/" + hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,45 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+)
+
+func TestGetClientFactoryKey(t *testing.T) {
+	certPath := filepath.Join(t.TempDir(), "cert.pem")
+	err := os.WriteFile(certPath, newTestCertificatePEM(t), 0600)
+	if err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+
+	opts := &types.TargetOptions{
+		AuthMethod:        types.AuthMethodClientCertificate,
+		TenantId:          "tenant-id-123",
+		ClientId:          "client-id-123",
+		ClientCertificate: certPath,
+		SubscriptionId:    "subscription-id-123",
+	}
+
+	key := GetClientFactoryKey(opts)
+	if key != GetClientFactoryKey(opts) {
+		t.Errorf("GetClientFactoryKey() is not stable")
+	}
+
+	err = os.WriteFile(certPath, newTestCertificatePEM(t), 0600)
+	if err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+
+	if key == GetClientFactoryKey(opts) {
+		t.Errorf("GetClientFactoryKey() did not change after the certificate was rotated")
+	}
+
+	otherSubscription := *opts
+	otherSubscription.SubscriptionId = "subscription-id-456"
+	if GetClientFactoryKey(&otherSubscription) == GetClientFactoryKey(opts) {
+		t.Errorf("GetClientFactoryKey() is the same for different subscriptions")
+	}
+}
@@ -7,43 +7,32 @@ import (
 	"io"
 	"strings"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
-	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	logwriters "github.com/daytonaio/daytona-provider-azure/internal/log"
 	"github.com/daytonaio/daytona-provider-azure/pkg/types"
 	"github.com/daytonaio/daytona/pkg/models"
-	"github.com/sethvargo/go-password/password"
 )
 
 const (
 	defaultResourceGroup = "daytona"
 )
 
-func initResourceGroup(opts *types.TargetOptions) (string, error) {
-	cred, err := getClientCredentials(opts)
-	if err != nil {
-		return "", fmt.Errorf("failed to get client credentials: %w", err)
-	}
-
-	client, err := armresources.NewResourceGroupsClient(opts.SubscriptionId, cred, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create resource groups client: %w", err)
-	}
+func initResourceGroup(opts *types.TargetOptions, clients *ClientFactory) (string, error) {
+	client := clients.resourceGroups
 
 	var resourceGroupName string
 
 	if opts.ResourceGroup != "" {
-		_, err = client.Get(context.Background(), opts.ResourceGroup, nil)
+		_, err := client.Get(context.Background(), opts.ResourceGroup, nil)
 		if err != nil {
 			return "", fmt.Errorf("failed to get resource group %s: %w", opts.ResourceGroup, err)
 		}
 		resourceGroupName = opts.ResourceGroup
 	} else {
-		_, err = client.Get(context.Background(), defaultResourceGroup, nil)
+		_, err := client.Get(context.Background(), defaultResourceGroup, nil)
 		if err != nil {
 			_, err = client.CreateOrUpdate(
 				context.Background(),
@@ -63,33 +52,77 @@ func initResourceGroup(opts *types.TargetOptions) (string, error) {
 }
 
 // createVirtualMachine creates a new virtual machine instance in the specified Azure workspace.
-func createVirtualMachine(targetId, resourceGroupName, customData string, opts *types.TargetOptions, cred azcore.TokenCredential, logWriter io.Writer) error {
-	spinner := logwriters.ShowSpinner(logWriter, "Creating Azure virtual network", "Azure virtual network created")
-	vNet, err := createVirtualNetwork(targetId, resourceGroupName, opts, cred)
-	close(spinner)
-	if err != nil {
-		return fmt.Errorf("cannot create virtual network: %+v", err)
+func createVirtualMachine(targetId, resourceGroupName, customData, sshPublicKey string, opts *types.TargetOptions, clients *ClientFactory, logWriter io.Writer) error {
+	var subnet *armnetwork.Subnet
+	if opts.SubnetId != "" {
+		subnet = &armnetwork.Subnet{ID: to.Ptr(opts.SubnetId)}
+	} else if opts.NetworkMode == types.NetworkModeShared {
+		spinner := logwriters.ShowSpinner(logWriter, "Creating Azure subnet in shared virtual network", "Azure subnet created")
+		var err error
+		subnet, err = createSharedSubnet(targetId, resourceGroupName, opts, clients)
+		close(spinner)
+		if err != nil {
+			return fmt.Errorf("cannot create subnet: %+v", err)
+		}
+	} else {
+		spinner := logwriters.ShowSpinner(logWriter, "Creating Azure virtual network", "Azure virtual network created")
+		vNet, err := createVirtualNetwork(targetId, resourceGroupName, opts, clients)
+		close(spinner)
+		if err != nil {
+			return fmt.Errorf("cannot create virtual network: %+v", err)
+		}
+
+		natGatewayId := ""
+		if opts.HasNATGateway() {
+			spinner = logwriters.ShowSpinner(logWriter, "Creating Azure NAT gateway", "Azure NAT gateway created")
+			natGatewayId, err = getSubnetNATGatewayId(targetId, resourceGroupName, opts, clients)
+			close(spinner)
+			if err != nil {
+				return err
+			}
+		}
+
+		spinner = logwriters.ShowSpinner(logWriter, "Creating Azure subnet", "Azure subnet created")
+		subnet, err = createSubnet(targetId, resourceGroupName, *vNet.Name, opts.GetSubnetPrefix(), natGatewayId, clients)
+		close(spinner)
+		if err != nil {
+			return fmt.Errorf("cannot create subnet: %+v", err)
+		}
 	}
 
-	spinner = logwriters.ShowSpinner(logWriter, "Creating Azure subnet", "Azure subnet created")
-	subnet, err := createSubnet(targetId, resourceGroupName, *vNet.Name, opts, cred)
+	if subnet.Properties != nil && subnet.Properties.NatGateway != nil && subnet.Properties.NatGateway.ID != nil {
+		egressIPs, err := getNATGatewayEgressIPs(*subnet.Properties.NatGateway.ID, clients)
+		if err != nil {
+			return fmt.Errorf("cannot get NAT gateway egress IP addresses: %+v", err)
+		}
+		logWriter.Write([]byte(fmt.Sprintf("Outbound traffic uses the NAT gateway egress IP addresses: %s\n", strings.Join(egressIPs, ", "))))
+	}
+
+	spinner := logwriters.ShowSpinner(logWriter, "Creating Azure network security group", "Azure network security group created")
+	nsg, err := createNetworkSecurityGroup(targetId, resourceGroupName, opts, clients)
 	close(spinner)
 	if err != nil {
-		return fmt.Errorf("cannot create subnet: %+v", err)
+		return fmt.Errorf("cannot create network security group: %+v", err)
+	}
+
+	var publicIPId string
+	if opts.HasPublicIP() {
+		spinner = logwriters.ShowSpinner(logWriter, "Creating Azure public IP address", "Azure public IP address created")
+		publicIP, err := createPublicIPAddress(targetId, resourceGroupName, opts, clients)
+		close(spinner)
+		if err != nil {
+			return fmt.Errorf("cannot create public IP address: %+v", err)
+		}
+		publicIPId = *publicIP.ID
 	}
 
 	spinner = logwriters.ShowSpinner(logWriter, "Creating Azure network interface", "Azure network interface created")
-	iface, err := createNetworkInterface(targetId, resourceGroupName, *subnet.ID, opts, cred)
+	iface, err := createNetworkInterface(targetId, resourceGroupName, *subnet.ID, *nsg.ID, publicIPId, opts, clients)
 	close(spinner)
 	if err != nil {
 		return fmt.Errorf("cannot create network interface:%+v", err)
 	}
 
-	computeClient, err := armcompute.NewVirtualMachinesClient(opts.SubscriptionId, cred, nil)
-	if err != nil {
-		return err
-	}
-
 	vmName := getResourceName(targetId)
 	vmDiskName := getResourceName(fmt.Sprintf("%s-disk", targetId))
 
@@ -98,79 +131,216 @@ func createVirtualMachine(targetId, resourceGroupName, customData string, opts *
 		return err
 	}
 
-	pwd, err := password.Generate(12, 3, 3, false, true)
-	if err != nil {
-		return err
-	}
-
-	spinner = logwriters.ShowSpinner(logWriter, "Creating Azure virtual machine", "Azure virtual machine created")
-	pollerResp, err := computeClient.BeginCreateOrUpdate(
-		context.Background(),
-		resourceGroupName,
-		vmName,
-		armcompute.VirtualMachine{
-			Location: &opts.Region,
-			Identity: &armcompute.VirtualMachineIdentity{
-				Type: to.Ptr(armcompute.ResourceIdentityTypeNone),
-			},
-			Properties: &armcompute.VirtualMachineProperties{
-				OSProfile: &armcompute.OSProfile{
-					ComputerName:  to.Ptr(vmName),
-					AdminUsername: to.Ptr("daytona"),
-					AdminPassword: to.Ptr(pwd),
-					CustomData:    to.Ptr(customData),
+	vm := armcompute.VirtualMachine{
+		Location: &opts.Region,
+		Identity: &armcompute.VirtualMachineIdentity{
+			Type: to.Ptr(armcompute.ResourceIdentityTypeNone),
+		},
+		Properties: &armcompute.VirtualMachineProperties{
+			OSProfile: &armcompute.OSProfile{
+				ComputerName:  to.Ptr(vmName),
+				AdminUsername: to.Ptr("daytona"),
+				CustomData:    to.Ptr(customData),
+				LinuxConfiguration: &armcompute.LinuxConfiguration{
+					DisablePasswordAuthentication: to.Ptr(true),
+					SSH: &armcompute.SSHConfiguration{
+						PublicKeys: []*armcompute.SSHPublicKey{
+							{
+								Path:    to.Ptr(authorizedKeysPath),
+								KeyData: to.Ptr(sshPublicKey),
+							},
+						},
+					},
 				},
-				HardwareProfile: &armcompute.HardwareProfile{
-					VMSize: to.Ptr(armcompute.VirtualMachineSizeTypes(opts.VMSize)),
+			},
+			HardwareProfile: &armcompute.HardwareProfile{},
+			StorageProfile: &armcompute.StorageProfile{
+				ImageReference: &armcompute.ImageReference{
+					Publisher: to.Ptr(publisher),
+					Offer:     to.Ptr(offer),
+					SKU:       to.Ptr(sku),
+					Version:   to.Ptr(version),
 				},
-				StorageProfile: &armcompute.StorageProfile{
-					ImageReference: &armcompute.ImageReference{
-						Publisher: to.Ptr(publisher),
-						Offer:     to.Ptr(offer),
-						SKU:       to.Ptr(sku),
-						Version:   to.Ptr(version),
-					},
-					OSDisk: &armcompute.OSDisk{
-						Name:         to.Ptr(vmDiskName),
-						CreateOption: to.Ptr(armcompute.DiskCreateOptionTypesFromImage),
-						Caching:      to.Ptr(armcompute.CachingTypesReadWrite),
-						ManagedDisk: &armcompute.ManagedDiskParameters{
-							StorageAccountType: to.Ptr(
-								armcompute.StorageAccountTypes(opts.DiskType),
-							),
-						},
-						DiskSizeGB: to.Ptr[int32](int32(opts.DiskSize)),
+				OSDisk: &armcompute.OSDisk{
+					Name:         to.Ptr(vmDiskName),
+					CreateOption: to.Ptr(armcompute.DiskCreateOptionTypesFromImage),
+					Caching:      to.Ptr(armcompute.CachingTypesReadWrite),
+					ManagedDisk: &armcompute.ManagedDiskParameters{
+						StorageAccountType: to.Ptr(
+							armcompute.StorageAccountTypes(opts.DiskType),
+						),
 					},
+					DiskSizeGB: to.Ptr[int32](int32(opts.DiskSize)),
 				},
-				NetworkProfile: &armcompute.NetworkProfile{
-					NetworkInterfaces: []*armcompute.NetworkInterfaceReference{
-						{
-							ID: iface.ID,
-						},
+			},
+			NetworkProfile: &armcompute.NetworkProfile{
+				NetworkInterfaces: []*armcompute.NetworkInterfaceReference{
+					{
+						ID: iface.ID,
 					},
 				},
 			},
-		}, nil)
+		},
+	}
+
+	if opts.GetSecurityType() != types.SecurityTypeStandard {
+		vm.Properties.SecurityProfile = &armcompute.SecurityProfile{
+			SecurityType: to.Ptr(armcompute.SecurityTypes(opts.GetSecurityType())),
+			UefiSettings: &armcompute.UefiSettings{
+				SecureBootEnabled: to.Ptr(opts.IsSecureBootEnabled()),
+				VTpmEnabled:       to.Ptr(opts.IsVTPMEnabled()),
+			},
+		}
+	}
+
+	if opts.GetSecurityType() == types.SecurityTypeConfidentialVM {
+		vm.Properties.StorageProfile.OSDisk.ManagedDisk.SecurityProfile = &armcompute.VMDiskSecurityProfile{
+			SecurityEncryptionType: to.Ptr(armcompute.SecurityEncryptionTypesVMGuestStateOnly),
+		}
+	}
+
+	if opts.IsSpot() {
+		vm.Properties.Priority = to.Ptr(armcompute.VirtualMachinePriorityTypesSpot)
+		vm.Properties.EvictionPolicy = to.Ptr(armcompute.VirtualMachineEvictionPolicyTypes(opts.GetEvictionPolicy()))
+		vm.Properties.BillingProfile = &armcompute.BillingProfile{
+			MaxPrice: to.Ptr(opts.GetMaxPrice()),
+		}
+	}
+
+	createInZones := func(vmSize string) error {
+		vm.Properties.HardwareProfile.VMSize = to.Ptr(armcompute.VirtualMachineSizeTypes(vmSize))
+		return createVirtualMachineInZones(targetId, resourceGroupName, vmSize, vm, opts, clients, logWriter)
+	}
+	deleteFailed := func() error {
+		return deleteFailedVirtualMachine(targetId, opts, clients)
+	}
+
+	return createWithVMSizeFallback(opts.VMSizeList(), createInZones, deleteFailed, logWriter)
+}
+
+// createWithVMSizeFallback creates the virtual machine with the VM sizes in order. When a
+// size cannot be allocated or would exceed a quota, the failed virtual machine is deleted
+// and the next size is tried. Other errors are returned right away.
+func createWithVMSizeFallback(vmSizes []string, create func(vmSize string) error, deleteFailed func() error, logWriter io.Writer) error {
+	for i, vmSize := range vmSizes {
+		err := create(vmSize)
+		if err == nil {
+			logWriter.Write([]byte(fmt.Sprintf("Virtual machine created with VM size %s\n", vmSize)))
+			return nil
+		}
+
+		if !(isAllocationError(err) || isQuotaError(err)) || i == len(vmSizes)-1 {
+			return err
+		}
+
+		logWriter.Write([]byte(fmt.Sprintf("VM size %s is not available (%s), trying %s\n", vmSize, getErrorCode(err), vmSizes[i+1])))
+
+		err = deleteFailed()
+		if err != nil {
+			return fmt.Errorf("cannot delete failed virtual machine: %w", err)
+		}
+	}
+
+	return fmt.Errorf("no VM size set")
+}
+
+// createVirtualMachineInZones creates the virtual machine with the given VM size, trying
+// the availability zones one by one while they have no capacity.
+func createVirtualMachineInZones(targetId, resourceGroupName, vmSize string, vm armcompute.VirtualMachine, opts *types.TargetOptions, clients *ClientFactory, logWriter io.Writer) error {
+	zones, err := getAvailabilityZones(vmSize, opts, clients)
 	if err != nil {
+		return err
+	}
+
+	vmName := getResourceName(targetId)
+
+	for i, zone := range zones {
+		if zone != "" {
+			vm.Zones = []*string{to.Ptr(zone)}
+		}
+
+		spinner := logwriters.ShowSpinner(logWriter, "Creating Azure virtual machine", "Azure virtual machine created")
+		pollerResp, err := clients.virtualMachines.BeginCreateOrUpdate(context.Background(), resourceGroupName, vmName, vm, nil)
+		if err == nil {
+			_, err = pollerResp.PollUntilDone(context.Background(), nil)
+		}
 		close(spinner)
+
+		if err == nil {
+			if zone != "" {
+				logWriter.Write([]byte(fmt.Sprintf("Virtual machine created in availability zone %s\n", zone)))
+			}
+			return nil
+		}
+
+		if !isAllocationError(err) || i == len(zones)-1 {
+			return err
+		}
+
+		logWriter.Write([]byte(fmt.Sprintf("Availability zone %s has no capacity for %s, trying zone %s\n", zone, vmSize, zones[i+1])))
+
+		err = deleteFailedVirtualMachine(targetId, opts, clients)
+		if err != nil {
+			return fmt.Errorf("cannot delete failed virtual machine: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// getAvailabilityZones returns the availability zones to create the virtual machine in,
+// in the order they are tried. An empty zone creates a regional virtual machine, which
+// is also used with "any" in regions without zones.
+func getAvailabilityZones(vmSize string, opts *types.TargetOptions, clients *ClientFactory) ([]string, error) {
+	switch opts.AvailabilityZone {
+	case "":
+		return []string{""}, nil
+	case types.AvailabilityZoneAny:
+		skus, err := listVirtualMachineSKUs(opts.Region, clients)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list VM sizes in %s: %w", opts.Region, err)
+		}
+
+		sku := findSKU(skus, vmSize)
+		if sku == nil {
+			return []string{""}, nil
+		}
+
+		zones := getSKUZones(sku, opts.Region)
+		if len(zones) == 0 {
+			return []string{""}, nil
+		}
+
+		return zones, nil
+	default:
+		return []string{opts.AvailabilityZone}, nil
+	}
+}
+
+// deleteFailedVirtualMachine deletes a virtual machine that failed to be allocated, and
+// its disk, so that it can be created again in another zone. The zone of an existing
+// virtual machine cannot be changed.
+func deleteFailedVirtualMachine(targetId string, opts *types.TargetOptions, clients *ClientFactory) error {
+	err := deleteVirtualMachine(targetId, opts, clients)
+	if err != nil && !isNotFoundError(err) {
 		return err
 	}
 
-	_, err = pollerResp.PollUntilDone(context.Background(), nil)
-	close(spinner)
-	return err
+	err = deleteDisk(targetId, opts, clients)
+	if err != nil && !isNotFoundError(err) {
+		return err
+	}
+
+	return nil
 }
 
 // createVirtualNetwork creates a virtual network in the specified resource group.
 // If the virtual network already exists, it returns the existing virtual network.
 // Otherwise, it creates a new virtual network.
-func createVirtualNetwork(targetId, resourceGroupName string, opts *types.TargetOptions, cred azcore.TokenCredential) (*armnetwork.VirtualNetwork, error) {
-	vnetClient, err := armnetwork.NewVirtualNetworksClient(opts.SubscriptionId, cred, nil)
-	if err != nil {
-		return nil, err
-	}
+func createVirtualNetwork(targetId, resourceGroupName string, opts *types.TargetOptions, clients *ClientFactory) (*armnetwork.VirtualNetwork, error) {
+	vnetClient := clients.virtualNetworks
 
-	vNetName := getResourceName(fmt.Sprintf("vnet-%s", targetId))
+	vNetName := getVirtualNetworkName(targetId, opts)
 	vNetResp, err := vnetClient.Get(context.Background(), resourceGroupName, vNetName, nil)
 	if err == nil {
 		return &vNetResp.VirtualNetwork, nil
@@ -186,9 +356,12 @@ func createVirtualNetwork(targetId, resourceGroupName string, opts *types.Target
 			Properties: &armnetwork.VirtualNetworkPropertiesFormat{
 				AddressSpace: &armnetwork.AddressSpace{
 					AddressPrefixes: []*string{
-						to.Ptr("10.10.0.0/16"),
+						to.Ptr(opts.GetAddressSpace()),
 					},
 				},
+				DhcpOptions: &armnetwork.DhcpOptions{
+					DNSServers: to.SliceOfPtrs(opts.GetDNSServers()...),
+				},
 			},
 		}, nil,
 	)
@@ -204,11 +377,19 @@ func createVirtualNetwork(targetId, resourceGroupName string, opts *types.Target
 	return &resp.VirtualNetwork, nil
 }
 
-// createSubnet creates a subnet for a virtual network.
-func createSubnet(targetId, resourceGroupName, vNetName string, opts *types.TargetOptions, cred azcore.TokenCredential) (*armnetwork.Subnet, error) {
-	subnetsClient, err := armnetwork.NewSubnetsClient(opts.SubscriptionId, cred, nil)
-	if err != nil {
-		return nil, err
+// createSubnet creates a subnet for a virtual network. The NAT gateway is attached to
+// the subnet when its ID is set.
+func createSubnet(targetId, resourceGroupName, vNetName, addressPrefix, natGatewayId string, clients *ClientFactory) (*armnetwork.Subnet, error) {
+	subnetsClient := clients.subnets
+
+	properties := &armnetwork.SubnetPropertiesFormat{
+		AddressPrefix: to.Ptr(addressPrefix),
+	}
+
+	if natGatewayId != "" {
+		properties.NatGateway = &armnetwork.SubResource{
+			ID: to.Ptr(natGatewayId),
+		}
 	}
 
 	subnetName := getResourceName(fmt.Sprintf("subnet-%s", targetId))
@@ -218,9 +399,7 @@ func createSubnet(targetId, resourceGroupName, vNetName string, opts *types.Targ
 		vNetName,
 		subnetName,
 		armnetwork.Subnet{
-			Properties: &armnetwork.SubnetPropertiesFormat{
-				AddressPrefix: to.Ptr("10.10.10.0/24"),
-			},
+			Properties: properties,
 		}, nil,
 	)
 	if err != nil {
@@ -235,11 +414,22 @@ func createSubnet(targetId, resourceGroupName, vNetName string, opts *types.Targ
 	return &resp.Subnet, nil
 }
 
-// createNetworkInterface creates a network interface.
-func createNetworkInterface(targetId, resourceGroupName, subnetId string, opts *types.TargetOptions, cred azcore.TokenCredential) (*armnetwork.Interface, error) {
-	nicClient, err := armnetwork.NewInterfacesClient(opts.SubscriptionId, cred, nil)
-	if err != nil {
-		return nil, err
+// createNetworkInterface creates a network interface protected by the given network security group.
+// The public IP address is attached to the interface when its ID is set.
+func createNetworkInterface(targetId, resourceGroupName, subnetId, nsgId, publicIPId string, opts *types.TargetOptions, clients *ClientFactory) (*armnetwork.Interface, error) {
+	nicClient := clients.interfaces
+
+	ipConfigProperties := &armnetwork.InterfaceIPConfigurationPropertiesFormat{
+		PrivateIPAllocationMethod: to.Ptr(armnetwork.IPAllocationMethodDynamic),
+		Subnet: &armnetwork.Subnet{
+			ID: to.Ptr(subnetId),
+		},
+	}
+
+	if publicIPId != "" {
+		ipConfigProperties.PublicIPAddress = &armnetwork.PublicIPAddress{
+			ID: to.Ptr(publicIPId),
+		}
 	}
 
 	ifaceName := getResourceName(fmt.Sprintf("iface-%s", targetId))
@@ -250,15 +440,14 @@ func createNetworkInterface(targetId, resourceGroupName, subnetId string, opts *
 		armnetwork.Interface{
 			Location: to.Ptr(opts.Region),
 			Properties: &armnetwork.InterfacePropertiesFormat{
+				EnableAcceleratedNetworking: to.Ptr(opts.AcceleratedNetworking),
+				NetworkSecurityGroup: &armnetwork.SecurityGroup{
+					ID: to.Ptr(nsgId),
+				},
 				IPConfigurations: []*armnetwork.InterfaceIPConfiguration{
 					{
-						Name: to.Ptr("ipConfig"),
-						Properties: &armnetwork.InterfaceIPConfigurationPropertiesFormat{
-							PrivateIPAllocationMethod: to.Ptr(armnetwork.IPAllocationMethodDynamic),
-							Subnet: &armnetwork.Subnet{
-								ID: to.Ptr(subnetId),
-							},
-						},
+						Name:       to.Ptr("ipConfig"),
+						Properties: ipConfigProperties,
 					},
 				},
 			},
@@ -276,16 +465,8 @@ func createNetworkInterface(targetId, resourceGroupName, subnetId string, opts *
 	return &resp.Interface, err
 }
 
-func GetVirtualMachine(target *models.Target, opts *types.TargetOptions) (*armcompute.VirtualMachine, error) {
-	cred, err := getClientCredentials(opts)
-	if err != nil {
-		return nil, err
-	}
-
-	computeClient, err := armcompute.NewVirtualMachinesClient(opts.SubscriptionId, cred, nil)
-	if err != nil {
-		return nil, err
-	}
+func GetVirtualMachine(target *models.Target, opts *types.TargetOptions, clients *ClientFactory) (*armcompute.VirtualMachine, error) {
+	computeClient := clients.virtualMachines
 
 	resourceGroupName := getResourceGroupName(opts)
 	vmName := getResourceName(target.Id)
@@ -298,17 +479,6 @@ func GetVirtualMachine(target *models.Target, opts *types.TargetOptions) (*armco
 	return &resp.VirtualMachine, nil
 }
 
-// getClientCredentials returns a new instance of azidentity.ClientSecretCredential
-// using the provided options.
-func getClientCredentials(opts *types.TargetOptions) (*azidentity.ClientSecretCredential, error) {
-	return azidentity.NewClientSecretCredential(
-		opts.TenantId,
-		opts.ClientId,
-		opts.ClientSecret,
-		nil,
-	)
-}
-
 // getResourceGroupName returns the resource group name from the given options.
 func getResourceGroupName(opts *types.TargetOptions) string {
 	if opts.ResourceGroup == "" {
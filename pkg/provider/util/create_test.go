@@ -0,0 +1,104 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCreateWithVMSizeFallback(t *testing.T) {
+	allocationErr := newResponseError(http.StatusConflict, "AllocationFailed", "Allocation failed.")
+	quotaErr := newResponseError(http.StatusConflict, "OperationNotAllowed", "Operation could not be completed as it results in exceeding approved standardDSv5Family Cores quota.")
+	otherErr := newResponseError(http.StatusBadRequest, "InvalidParameter", "The value of parameter imageReference is invalid.")
+	cleanupErr := errors.New("delete failed")
+
+	tests := []struct {
+		name        string
+		vmSizes     []string
+		createErrs  map[string]error
+		cleanupErr  error
+		wantCalls   []string
+		wantCleanup int
+		wantErr     error
+	}{
+		{
+			name:      "First size succeeds",
+			vmSizes:   []string{"Standard_D2s_v5", "Standard_D2as_v5"},
+			wantCalls: []string{"Standard_D2s_v5"},
+		},
+		{
+			name:        "Allocation failure falls back to the next size",
+			vmSizes:     []string{"Standard_D2s_v5", "Standard_D2as_v5", "Standard_D2s_v4"},
+			createErrs:  map[string]error{"Standard_D2s_v5": allocationErr},
+			wantCalls:   []string{"Standard_D2s_v5", "Standard_D2as_v5"},
+			wantCleanup: 1,
+		},
+		{
+			name:        "Quota failure falls back to the next size",
+			vmSizes:     []string{"Standard_D2s_v5", "Standard_D2as_v5", "Standard_D2s_v4"},
+			createErrs:  map[string]error{"Standard_D2s_v5": quotaErr, "Standard_D2as_v5": allocationErr},
+			wantCalls:   []string{"Standard_D2s_v5", "Standard_D2as_v5", "Standard_D2s_v4"},
+			wantCleanup: 2,
+		},
+		{
+			name:       "Other errors stop the fallback",
+			vmSizes:    []string{"Standard_D2s_v5", "Standard_D2as_v5"},
+			createErrs: map[string]error{"Standard_D2s_v5": otherErr},
+			wantCalls:  []string{"Standard_D2s_v5"},
+			wantErr:    otherErr,
+		},
+		{
+			name:        "Last size fails",
+			vmSizes:     []string{"Standard_D2s_v5", "Standard_D2as_v5"},
+			createErrs:  map[string]error{"Standard_D2s_v5": allocationErr, "Standard_D2as_v5": quotaErr},
+			wantCalls:   []string{"Standard_D2s_v5", "Standard_D2as_v5"},
+			wantCleanup: 1,
+			wantErr:     quotaErr,
+		},
+		{
+			name:        "Cleanup failure stops the fallback",
+			vmSizes:     []string{"Standard_D2s_v5", "Standard_D2as_v5"},
+			createErrs:  map[string]error{"Standard_D2s_v5": allocationErr},
+			cleanupErr:  cleanupErr,
+			wantCalls:   []string{"Standard_D2s_v5"},
+			wantCleanup: 1,
+			wantErr:     cleanupErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			cleanups := 0
+
+			create := func(vmSize string) error {
+				calls = append(calls, vmSize)
+				return tt.createErrs[vmSize]
+			}
+			deleteFailed := func() error {
+				cleanups++
+				return tt.cleanupErr
+			}
+
+			err := createWithVMSizeFallback(tt.vmSizes, create, deleteFailed, &bytes.Buffer{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("createWithVMSizeFallback() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("createWithVMSizeFallback() tried %v, want %v", calls, tt.wantCalls)
+			}
+			if cleanups != tt.wantCleanup {
+				t.Errorf("createWithVMSizeFallback() deleted the failed virtual machine %d times, want %d", cleanups, tt.wantCleanup)
+			}
+		})
+	}
+
+	t.Run("No VM size", func(t *testing.T) {
+		err := createWithVMSizeFallback(nil, func(string) error { return nil }, func() error { return nil }, &bytes.Buffer{})
+		if err == nil {
+			t.Errorf("createWithVMSizeFallback() expected an error without VM sizes")
+		}
+	})
+}
@@ -0,0 +1,163 @@
+package util
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+)
+
+// getClientCredentials returns a token credential for the auth method selected
+// in the provided options. An empty auth method falls back to client secret
+// authentication.
+func getClientCredentials(opts *types.TargetOptions) (azcore.TokenCredential, error) {
+	cloudConfig, err := getCloudConfiguration(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	clientOptions := azcore.ClientOptions{Cloud: cloudConfig}
+	disableInstanceDiscovery := opts.Cloud == types.CloudCustom
+
+	switch opts.AuthMethod {
+	case "", types.AuthMethodClientSecret:
+		return azidentity.NewClientSecretCredential(
+			opts.TenantId,
+			opts.ClientId,
+			opts.ClientSecret,
+			&azidentity.ClientSecretCredentialOptions{
+				ClientOptions:            clientOptions,
+				DisableInstanceDiscovery: disableInstanceDiscovery,
+			},
+		)
+	case types.AuthMethodClientCertificate:
+		certData, err := loadClientCertificate(opts.ClientCertificate)
+		if err != nil {
+			return nil, err
+		}
+
+		certs, key, err := parseClientCertificate(certData, opts.ClientCertificatePassword)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse client certificate: %w", err)
+		}
+
+		return azidentity.NewClientCertificateCredential(
+			opts.TenantId,
+			opts.ClientId,
+			certs,
+			key,
+			&azidentity.ClientCertificateCredentialOptions{
+				ClientOptions:            clientOptions,
+				DisableInstanceDiscovery: disableInstanceDiscovery,
+			},
+		)
+	case types.AuthMethodWorkloadIdentity:
+		// The token file is only read when a token is requested, so a wrong path is
+		// reported here instead of on the first Azure request
+		_, err := os.Stat(opts.FederatedTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read federated token file: %w", err)
+		}
+
+		return azidentity.NewWorkloadIdentityCredential(&azidentity.WorkloadIdentityCredentialOptions{
+			ClientOptions:            clientOptions,
+			TenantID:                 opts.TenantId,
+			ClientID:                 opts.ClientId,
+			TokenFilePath:            opts.FederatedTokenFile,
+			DisableInstanceDiscovery: disableInstanceDiscovery,
+		})
+	case types.AuthMethodManagedIdentity:
+		options := azidentity.ManagedIdentityCredentialOptions{ClientOptions: clientOptions}
+		if opts.ClientId != "" {
+			options.ID = azidentity.ClientID(opts.ClientId)
+		}
+		return azidentity.NewManagedIdentityCredential(&options)
+	case types.AuthMethodAzureCLI:
+		return azidentity.NewAzureCLICredential(&azidentity.AzureCLICredentialOptions{
+			TenantID: opts.TenantId,
+		})
+	case types.AuthMethodEnvironment:
+		return azidentity.NewEnvironmentCredential(&azidentity.EnvironmentCredentialOptions{
+			ClientOptions:            clientOptions,
+			DisableInstanceDiscovery: disableInstanceDiscovery,
+		})
+	case types.AuthMethodDefault:
+		return azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{
+			ClientOptions:            clientOptions,
+			TenantID:                 opts.TenantId,
+			DisableInstanceDiscovery: disableInstanceDiscovery,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported auth method: %s", opts.AuthMethod)
+	}
+}
+
+// loadClientCertificate returns the certificate data for the given value, which
+// is either an inline PEM certificate or a path to a PEM/PFX file.
+func loadClientCertificate(certificate string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(certificate), "-----BEGIN") {
+		return []byte(certificate), nil
+	}
+
+	certData, err := os.ReadFile(certificate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client certificate: %w", err)
+	}
+
+	return certData, nil
+}
+
+// parseClientCertificate returns the certificates and private key of a PEM or PFX
+// certificate. The password is only used for PFX files, so that a password set in the
+// environment for other certificates does not break unencrypted PEM files, which are the
+// only PEM files the Azure SDK can read.
+func parseClientCertificate(certData []byte, password string) ([]*x509.Certificate, crypto.PrivateKey, error) {
+	if strings.Contains(string(certData), "-----BEGIN") {
+		for block, rest := pem.Decode(certData); block != nil; block, rest = pem.Decode(rest) {
+			if block.Type == "ENCRYPTED PRIVATE KEY" || strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED") {
+				return nil, nil, fmt.Errorf("encrypted PEM private keys are not supported, use a PFX file or an unencrypted PEM file")
+			}
+		}
+
+		return azidentity.ParseCertificates(certData, nil)
+	}
+
+	return azidentity.ParseCertificates(certData, []byte(password))
+}
+
+// getCloudConfiguration returns the cloud configuration for the cloud selected in the
+// provided options. Custom clouds are built from the explicit endpoint options.
+func getCloudConfiguration(opts *types.TargetOptions) (cloud.Configuration, error) {
+	switch opts.Cloud {
+	case "", types.CloudAzurePublic:
+		return cloud.AzurePublic, nil
+	case types.CloudAzureGovernment:
+		return cloud.AzureGovernment, nil
+	case types.CloudAzureChina:
+		return cloud.AzureChina, nil
+	case types.CloudCustom:
+		audience := opts.ARMAudience
+		if audience == "" {
+			audience = opts.ARMEndpoint
+		}
+
+		return cloud.Configuration{
+			ActiveDirectoryAuthorityHost: opts.AuthorityHost,
+			Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+				cloud.ResourceManager: {
+					Endpoint: opts.ARMEndpoint,
+					Audience: audience,
+				},
+			},
+		}, nil
+	default:
+		return cloud.Configuration{}, fmt.Errorf("unsupported cloud: %s", opts.Cloud)
+	}
+}
@@ -0,0 +1,295 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+)
+
+func TestGetClientCredentials(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     *types.TargetOptions
+		wantType string
+		wantErr  bool
+	}{
+		{
+			name:     "Default auth method",
+			opts:     &types.TargetOptions{TenantId: "tenant-id-123", ClientId: "client-id-123", ClientSecret: "client-secret-123"},
+			wantType: fmt.Sprintf("%T", &azidentity.ClientSecretCredential{}),
+		},
+		{
+			name:     "Client secret",
+			opts:     &types.TargetOptions{AuthMethod: types.AuthMethodClientSecret, TenantId: "tenant-id-123", ClientId: "client-id-123", ClientSecret: "client-secret-123"},
+			wantType: fmt.Sprintf("%T", &azidentity.ClientSecretCredential{}),
+		},
+		{
+			name:     "Managed identity",
+			opts:     &types.TargetOptions{AuthMethod: types.AuthMethodManagedIdentity},
+			wantType: fmt.Sprintf("%T", &azidentity.ManagedIdentityCredential{}),
+		},
+		{
+			name:     "User-assigned managed identity",
+			opts:     &types.TargetOptions{AuthMethod: types.AuthMethodManagedIdentity, ClientId: "client-id-123"},
+			wantType: fmt.Sprintf("%T", &azidentity.ManagedIdentityCredential{}),
+		},
+		{
+			name:     "Azure CLI",
+			opts:     &types.TargetOptions{AuthMethod: types.AuthMethodAzureCLI},
+			wantType: fmt.Sprintf("%T", &azidentity.AzureCLICredential{}),
+		},
+		{
+			name:    "Unsupported auth method",
+			opts:    &types.TargetOptions{AuthMethod: "Password"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getClientCredentials(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getClientCredentials() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if gotType := fmt.Sprintf("%T", got); gotType != tt.wantType {
+				t.Errorf("getClientCredentials() = %v, want %v", gotType, tt.wantType)
+			}
+		})
+	}
+}
+
+func newTestCertificatePEM(t *testing.T) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "daytona"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+
+	return append(certPEM, keyPEM...)
+}
+
+func TestGetClientCertificateCredentials(t *testing.T) {
+	dir := t.TempDir()
+	certPEM := newTestCertificatePEM(t)
+
+	certPath := filepath.Join(dir, "cert.pem")
+	err := os.WriteFile(certPath, certPEM, 0600)
+	if err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+
+	invalidPath := filepath.Join(dir, "invalid.pem")
+	err = os.WriteFile(invalidPath, []byte("not a certificate"), 0600)
+	if err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+
+	encryptedPath := filepath.Join(dir, "encrypted.pem")
+	encryptedKey := pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte("key")})
+	err = os.WriteFile(encryptedPath, encryptedKey, 0600)
+	if err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		certificate string
+		password    string
+		wantErr     bool
+	}{
+		{
+			name:        "PEM file",
+			certificate: certPath,
+		},
+		{
+			name:        "PEM file with password",
+			certificate: certPath,
+			password:    "password",
+		},
+		{
+			name:        "Inline PEM",
+			certificate: string(certPEM),
+		},
+		{
+			name:        "Missing file",
+			certificate: filepath.Join(dir, "missing.pem"),
+			wantErr:     true,
+		},
+		{
+			name:        "Invalid file",
+			certificate: invalidPath,
+			wantErr:     true,
+		},
+		{
+			name:        "Encrypted PEM private key",
+			certificate: encryptedPath,
+			password:    "password",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &types.TargetOptions{
+				AuthMethod:                types.AuthMethodClientCertificate,
+				TenantId:                  "tenant-id-123",
+				ClientId:                  "client-id-123",
+				ClientCertificate:         tt.certificate,
+				ClientCertificatePassword: tt.password,
+			}
+
+			got, err := getClientCredentials(opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getClientCredentials() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if _, ok := got.(*azidentity.ClientCertificateCredential); !ok {
+				t.Errorf("getClientCredentials() = %T, want a client certificate credential", got)
+			}
+		})
+	}
+}
+
+func TestGetWorkloadIdentityCredentials(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	err := os.WriteFile(tokenFile, []byte("token"), 0600)
+	if err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		tokenFile string
+		wantErr   bool
+	}{
+		{
+			name:      "Token file",
+			tokenFile: tokenFile,
+		},
+		{
+			name:      "Missing token file",
+			tokenFile: filepath.Join(t.TempDir(), "missing"),
+			wantErr:   true,
+		},
+		{
+			name:      "No token file",
+			tokenFile: "",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &types.TargetOptions{
+				AuthMethod:         types.AuthMethodWorkloadIdentity,
+				TenantId:           "tenant-id-123",
+				ClientId:           "client-id-123",
+				FederatedTokenFile: tt.tokenFile,
+			}
+
+			got, err := getClientCredentials(opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getClientCredentials() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if _, ok := got.(*azidentity.WorkloadIdentityCredential); !ok {
+				t.Errorf("getClientCredentials() = %T, want a workload identity credential", got)
+			}
+		})
+	}
+}
+
+func TestGetCloudConfiguration(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         *types.TargetOptions
+		wantEndpoint string
+		wantAudience string
+		wantErr      bool
+	}{
+		{
+			name:         "Default cloud",
+			opts:         &types.TargetOptions{},
+			wantEndpoint: cloud.AzurePublic.Services[cloud.ResourceManager].Endpoint,
+			wantAudience: cloud.AzurePublic.Services[cloud.ResourceManager].Audience,
+		},
+		{
+			name:         "Azure Government",
+			opts:         &types.TargetOptions{Cloud: types.CloudAzureGovernment},
+			wantEndpoint: cloud.AzureGovernment.Services[cloud.ResourceManager].Endpoint,
+			wantAudience: cloud.AzureGovernment.Services[cloud.ResourceManager].Audience,
+		},
+		{
+			name:         "Custom cloud",
+			opts:         &types.TargetOptions{Cloud: types.CloudCustom, ARMEndpoint: "https://management.local.azurestack.external", AuthorityHost: "https://login.local.azurestack.external"},
+			wantEndpoint: "https://management.local.azurestack.external",
+			wantAudience: "https://management.local.azurestack.external",
+		},
+		{
+			name:         "Custom cloud with audience",
+			opts:         &types.TargetOptions{Cloud: types.CloudCustom, ARMEndpoint: "https://management.local.azurestack.external", ARMAudience: "https://management.adfs.azurestack.local/"},
+			wantEndpoint: "https://management.local.azurestack.external",
+			wantAudience: "https://management.adfs.azurestack.local/",
+		},
+		{
+			name:    "Unknown cloud",
+			opts:    &types.TargetOptions{Cloud: "AzureGermany"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getCloudConfiguration(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getCloudConfiguration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			resourceManager := got.Services[cloud.ResourceManager]
+			if resourceManager.Endpoint != tt.wantEndpoint || resourceManager.Audience != tt.wantAudience {
+				t.Errorf("getCloudConfiguration() = %v, want endpoint %v and audience %v", resourceManager, tt.wantEndpoint, tt.wantAudience)
+			}
+		})
+	}
+}
@@ -4,20 +4,14 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
 	"github.com/daytonaio/daytona-provider-azure/pkg/types"
 )
 
 // deleteVirtualMachine deletes a virtual machine instance in the specified resource group and workspace.
-func deleteVirtualMachine(targetId string, opts *types.TargetOptions, cred azcore.TokenCredential) error {
+func deleteVirtualMachine(targetId string, opts *types.TargetOptions, clients *ClientFactory) error {
 	resourceGroupName := getResourceGroupName(opts)
 
-	computeClient, err := armcompute.NewVirtualMachinesClient(opts.SubscriptionId, cred, nil)
-	if err != nil {
-		return err
-	}
+	computeClient := clients.virtualMachines
 
 	vmName := getResourceName(targetId)
 
@@ -31,13 +25,10 @@ func deleteVirtualMachine(targetId string, opts *types.TargetOptions, cred azcor
 }
 
 // deleteDisk deletes a disk associated with virtual machine instance in a workspace.
-func deleteDisk(targetId string, opts *types.TargetOptions, cred azcore.TokenCredential) error {
+func deleteDisk(targetId string, opts *types.TargetOptions, clients *ClientFactory) error {
 	resourceGroupName := getResourceGroupName(opts)
 
-	diskClient, err := armcompute.NewDisksClient(opts.SubscriptionId, cred, nil)
-	if err != nil {
-		return err
-	}
+	diskClient := clients.disks
 
 	vmDiskName := getResourceName(fmt.Sprintf("%s-disk", targetId))
 
@@ -51,11 +42,8 @@ func deleteDisk(targetId string, opts *types.TargetOptions, cred azcore.TokenCre
 }
 
 // deleteVirtualNetwork deletes a virtual network in the specified resource group and workspace.
-func deleteVirtualNetwork(vNetName string, opts *types.TargetOptions, cred azcore.TokenCredential) error {
-	vnetClient, err := armnetwork.NewVirtualNetworksClient(opts.SubscriptionId, cred, nil)
-	if err != nil {
-		return err
-	}
+func deleteVirtualNetwork(vNetName string, opts *types.TargetOptions, clients *ClientFactory) error {
+	vnetClient := clients.virtualNetworks
 
 	resourceGroupName := getResourceGroupName(opts)
 	pollerResp, err := vnetClient.BeginDelete(context.Background(), resourceGroupName, vNetName, nil)
@@ -68,11 +56,8 @@ func deleteVirtualNetwork(vNetName string, opts *types.TargetOptions, cred azcor
 }
 
 // deleteSubnet deletes a subnet in a specified virtual network and resource group.
-func deleteSubnet(vNetName, subnetName string, opts *types.TargetOptions, cred azcore.TokenCredential) error {
-	subnetsClient, err := armnetwork.NewSubnetsClient(opts.SubscriptionId, cred, nil)
-	if err != nil {
-		return err
-	}
+func deleteSubnet(vNetName, subnetName string, opts *types.TargetOptions, clients *ClientFactory) error {
+	subnetsClient := clients.subnets
 
 	resourceGroupName := getResourceGroupName(opts)
 	pollerResp, err := subnetsClient.BeginDelete(context.Background(), resourceGroupName, vNetName, subnetName, nil)
@@ -85,14 +70,11 @@ func deleteSubnet(vNetName, subnetName string, opts *types.TargetOptions, cred a
 }
 
 // deleteNetworkInterface deletes a network interface in a specified Azure subscription
-// and resource group. It uses the given workspace ID, target options, and Azure Token
-// Credential to authenticate the request. The function returns an error if the deletion
+// and resource group. It uses the given workspace ID, target options, and the shared
+// ARM clients to authenticate the request. The function returns an error if the deletion
 // process encounters any errors.
-func deleteNetworkInterface(targetId string, opts *types.TargetOptions, cred azcore.TokenCredential) error {
-	nicClient, err := armnetwork.NewInterfacesClient(opts.SubscriptionId, cred, nil)
-	if err != nil {
-		return err
-	}
+func deleteNetworkInterface(targetId string, opts *types.TargetOptions, clients *ClientFactory) error {
+	nicClient := clients.interfaces
 
 	resourceGroupName := getResourceGroupName(opts)
 	ifaceName := getResourceName(fmt.Sprintf("iface-%s", targetId))
@@ -0,0 +1,160 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+	"github.com/daytonaio/daytona/pkg/models"
+)
+
+const (
+	dnsRecordTTL         = 300
+	dnsRecordTargetIdKey = "daytonaTargetId"
+)
+
+var invalidDNSLabelChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// getDNSRecordName returns the DNS label of the target, derived from its name.
+func getDNSRecordName(target *models.Target) string {
+	name := invalidDNSLabelChars.ReplaceAllString(strings.ToLower(target.Name), "-")
+	if len(name) > 63 {
+		name = name[:63]
+	}
+
+	name = strings.Trim(name, "-")
+	if name == "" {
+		return strings.ToLower(target.Id)
+	}
+
+	return name
+}
+
+// getPrivateDNSName returns the fully qualified name of the target in the Private DNS zone.
+func getPrivateDNSName(target *models.Target, opts *types.TargetOptions) (string, error) {
+	zoneId, err := arm.ParseResourceID(opts.PrivateDNSZoneId)
+	if err != nil {
+		return "", fmt.Errorf("invalid private DNS zone id %s: %w", opts.PrivateDNSZoneId, err)
+	}
+
+	return getDNSRecordName(target) + "." + zoneId.Name, nil
+}
+
+// registerPrivateDNSRecord creates an A record for the private IP address of the target
+// in the Private DNS zone. The zone can be shared with other targets and workloads, so an
+// existing record with the same name is only overwritten when it was created for the target,
+// e.g. by an earlier attempt to create it.
+func registerPrivateDNSRecord(target *models.Target, opts *types.TargetOptions, clients *ClientFactory) error {
+	zoneId, err := arm.ParseResourceID(opts.PrivateDNSZoneId)
+	if err != nil {
+		return fmt.Errorf("invalid private DNS zone id %s: %w", opts.PrivateDNSZoneId, err)
+	}
+
+	ifaceName := getResourceName(fmt.Sprintf("iface-%s", target.Id))
+	iface, err := clients.interfaces.Get(context.Background(), getResourceGroupName(opts), ifaceName, nil)
+	if err != nil {
+		return err
+	}
+
+	var privateIP *string
+	if iface.Properties != nil && len(iface.Properties.IPConfigurations) > 0 && iface.Properties.IPConfigurations[0].Properties != nil {
+		privateIP = iface.Properties.IPConfigurations[0].Properties.PrivateIPAddress
+	}
+	if privateIP == nil {
+		return fmt.Errorf("network interface %s has no private IP address", ifaceName)
+	}
+
+	recordSetsClient, err := clients.getPrivateDNSRecordSetsClient(zoneId.SubscriptionID)
+	if err != nil {
+		return err
+	}
+
+	recordName := getDNSRecordName(target)
+	recordSet := armprivatedns.RecordSet{
+		Properties: &armprivatedns.RecordSetProperties{
+			TTL: to.Ptr[int64](dnsRecordTTL),
+			ARecords: []*armprivatedns.ARecord{
+				{
+					IPv4Address: privateIP,
+				},
+			},
+			Metadata: map[string]*string{
+				dnsRecordTargetIdKey: to.Ptr(target.Id),
+			},
+		},
+	}
+
+	_, err = recordSetsClient.CreateOrUpdate(context.Background(), zoneId.ResourceGroupName, zoneId.Name, armprivatedns.RecordTypeA, recordName, recordSet,
+		&armprivatedns.RecordSetsClientCreateOrUpdateOptions{
+			IfNoneMatch: to.Ptr("*"),
+		},
+	)
+	if !isPreconditionFailedError(err) {
+		return err
+	}
+
+	resp, err := recordSetsClient.Get(context.Background(), zoneId.ResourceGroupName, zoneId.Name, armprivatedns.RecordTypeA, recordName, nil)
+	if err != nil {
+		return err
+	}
+
+	if !isTargetDNSRecord(&resp.RecordSet, target.Id) {
+		return fmt.Errorf("the name %s is already used in private DNS zone %s. Rename the target or delete the existing record", recordName, zoneId.Name)
+	}
+
+	_, err = recordSetsClient.CreateOrUpdate(context.Background(), zoneId.ResourceGroupName, zoneId.Name, armprivatedns.RecordTypeA, recordName, recordSet,
+		&armprivatedns.RecordSetsClientCreateOrUpdateOptions{
+			IfMatch: resp.Etag,
+		},
+	)
+	return err
+}
+
+// deletePrivateDNSRecord deletes the A record of the target from the Private DNS zone. A
+// record with the same name that was not created for the target is left untouched.
+func deletePrivateDNSRecord(target *models.Target, opts *types.TargetOptions, clients *ClientFactory) error {
+	zoneId, err := arm.ParseResourceID(opts.PrivateDNSZoneId)
+	if err != nil {
+		return fmt.Errorf("invalid private DNS zone id %s: %w", opts.PrivateDNSZoneId, err)
+	}
+
+	recordSetsClient, err := clients.getPrivateDNSRecordSetsClient(zoneId.SubscriptionID)
+	if err != nil {
+		return err
+	}
+
+	recordName := getDNSRecordName(target)
+	resp, err := recordSetsClient.Get(context.Background(), zoneId.ResourceGroupName, zoneId.Name, armprivatedns.RecordTypeA, recordName, nil)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+
+	if !isTargetDNSRecord(&resp.RecordSet, target.Id) {
+		return nil
+	}
+
+	_, err = recordSetsClient.Delete(context.Background(), zoneId.ResourceGroupName, zoneId.Name, armprivatedns.RecordTypeA, recordName,
+		&armprivatedns.RecordSetsClientDeleteOptions{
+			IfMatch: resp.Etag,
+		},
+	)
+	return err
+}
+
+// isTargetDNSRecord reports whether the record set was created for the target.
+func isTargetDNSRecord(recordSet *armprivatedns.RecordSet, targetId string) bool {
+	if recordSet.Properties == nil {
+		return false
+	}
+
+	id, ok := recordSet.Properties.Metadata[dnsRecordTargetIdKey]
+	return ok && id != nil && *id == targetId
+}
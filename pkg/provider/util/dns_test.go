@@ -0,0 +1,50 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
+)
+
+func TestIsTargetDNSRecord(t *testing.T) {
+	tests := []struct {
+		name      string
+		recordSet *armprivatedns.RecordSet
+		want      bool
+	}{
+		{
+			name: "Record of the target",
+			recordSet: &armprivatedns.RecordSet{
+				Properties: &armprivatedns.RecordSetProperties{
+					Metadata: map[string]*string{dnsRecordTargetIdKey: to.Ptr("target-id")},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Record of another target",
+			recordSet: &armprivatedns.RecordSet{
+				Properties: &armprivatedns.RecordSetProperties{
+					Metadata: map[string]*string{dnsRecordTargetIdKey: to.Ptr("other-target-id")},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Record without metadata",
+			recordSet: &armprivatedns.RecordSet{
+				Properties: &armprivatedns.RecordSetProperties{},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTargetDNSRecord(tt.recordSet, "target-id"); got != tt.want {
+				t.Errorf("isTargetDNSRecord() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
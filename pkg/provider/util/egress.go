@@ -0,0 +1,320 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+	"github.com/daytonaio/daytona/pkg/models"
+)
+
+const (
+	serviceTagInternet       = "Internet"
+	serviceTagVirtualNetwork = "VirtualNetwork"
+)
+
+// serverURLEnvVars are the target environment variables that hold the Daytona control
+// plane and tailnet coordination endpoints the agent connects to.
+var serverURLEnvVars = []string{"DAYTONA_SERVER_URL", "DAYTONA_SERVER_API_URL"}
+
+// egressEndpoint is a TCP endpoint the target has to reach.
+type egressEndpoint struct {
+	host string
+	port int
+}
+
+// carrierGradeNATPrefix is the shared address space of RFC 6598, which is not routed on
+// the internet.
+var carrierGradeNATPrefix = netip.MustParsePrefix("100.64.0.0/10")
+
+// validateEgressAllowList checks that the egress allow list lets the target reach the
+// Daytona server, or the proxies when they are set, so the agent can still connect.
+func validateEgressAllowList(target *models.Target, opts *types.TargetOptions, clients *ClientFactory) error {
+	if opts.EgressPolicy != types.EgressPolicyAllowList {
+		return nil
+	}
+
+	rules, err := types.ParseSecurityRules(opts.EgressAllowList)
+	if err != nil {
+		return err
+	}
+
+	endpoints, err := getRequiredEgressEndpoints(target.EnvVars, opts)
+	if err != nil {
+		return err
+	}
+
+	addressSpace, err := getVirtualNetworkAddressSpace(target.Id, opts, clients)
+	if err != nil {
+		return err
+	}
+
+	serviceTags := newServiceTagResolver(opts.Region, clients)
+
+	for _, endpoint := range endpoints {
+		ips, err := net.LookupIP(endpoint.host)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", endpoint.host, err)
+		}
+
+		for _, ip := range ips {
+			addr, ok := netip.AddrFromSlice(ip)
+			if !ok || !addr.Unmap().Is4() {
+				continue
+			}
+			addr = addr.Unmap()
+
+			allowed, err := isEgressAllowed(addr, endpoint.port, rules, addressSpace, serviceTags)
+			if err != nil {
+				return err
+			}
+
+			if !allowed {
+				return fmt.Errorf("the egress allow list blocks %s (%s) on port %d, which the agent needs to connect. Add e.g. tcp:%d:%s/32",
+					endpoint.host, addr, endpoint.port, endpoint.port, addr)
+			}
+		}
+	}
+
+	return nil
+}
+
+// getVirtualNetworkAddressSpace returns the address prefixes of the virtual network the
+// target is placed in, which the VirtualNetwork service tag matches. The virtual network
+// of an existing subnet and an existing shared virtual network can have a different
+// address space than the Address Space option.
+func getVirtualNetworkAddressSpace(targetId string, opts *types.TargetOptions, clients *ClientFactory) ([]netip.Prefix, error) {
+	addressPrefixes := []*string{to.Ptr(opts.GetAddressSpace())}
+
+	if opts.SubnetId != "" {
+		vNet, _, err := getExistingSubnet(opts.SubnetId, clients)
+		if err != nil {
+			return nil, err
+		}
+
+		addressPrefixes = getAddressPrefixes(vNet)
+	} else if opts.NetworkMode == types.NetworkModeShared {
+		resp, err := clients.virtualNetworks.Get(context.Background(), getResourceGroupName(opts), getVirtualNetworkName(targetId, opts), nil)
+		if err != nil && !isNotFoundError(err) {
+			return nil, fmt.Errorf("failed to get shared virtual network: %w", err)
+		}
+
+		if err == nil {
+			addressPrefixes = getAddressPrefixes(&resp.VirtualNetwork)
+		}
+	}
+
+	prefixes := []netip.Prefix{}
+	for _, addressPrefix := range addressPrefixes {
+		if addressPrefix == nil {
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(*addressPrefix)
+		if err != nil {
+			continue
+		}
+		prefixes = append(prefixes, prefix)
+	}
+
+	return prefixes, nil
+}
+
+func getAddressPrefixes(vNet *armnetwork.VirtualNetwork) []*string {
+	if vNet.Properties == nil || vNet.Properties.AddressSpace == nil {
+		return nil
+	}
+
+	return vNet.Properties.AddressSpace.AddressPrefixes
+}
+
+// getRequiredEgressEndpoints returns the endpoints the agent connects to. When a proxy is
+// set, the agent connects through it instead of connecting to the server directly.
+func getRequiredEgressEndpoints(envVars map[string]string, opts *types.TargetOptions) ([]egressEndpoint, error) {
+	urls := []string{}
+	if opts.HasProxy() {
+		urls = append(urls, opts.HTTPProxy, opts.HTTPSProxy)
+	} else {
+		for _, envVar := range serverURLEnvVars {
+			urls = append(urls, envVars[envVar])
+		}
+	}
+
+	endpoints := []egressEndpoint{}
+	for _, rawURL := range urls {
+		if rawURL == "" {
+			continue
+		}
+
+		endpoint, err := parseEgressEndpoint(rawURL)
+		if err != nil {
+			return nil, err
+		}
+
+		endpoints = append(endpoints, endpoint)
+	}
+
+	return endpoints, nil
+}
+
+// parseEgressEndpoint returns the host and port of the URL, defaulting the port from the
+// URL scheme.
+func parseEgressEndpoint(rawURL string) (egressEndpoint, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return egressEndpoint{}, fmt.Errorf("invalid URL %s", rawURL)
+	}
+
+	port := 443
+	if u.Scheme == "http" {
+		port = 80
+	}
+
+	if u.Port() != "" {
+		port, err = strconv.Atoi(u.Port())
+		if err != nil {
+			return egressEndpoint{}, fmt.Errorf("invalid port in URL %s", rawURL)
+		}
+	}
+
+	return egressEndpoint{host: u.Hostname(), port: port}, nil
+}
+
+// isEgressAllowed reports whether one of the rules allows TCP traffic to the address and
+// port. The rules are only allow rules, so their order does not matter.
+func isEgressAllowed(addr netip.Addr, port int, rules []types.SecurityRule, addressSpace []netip.Prefix, serviceTags func(string) ([]netip.Prefix, error)) (bool, error) {
+	for _, rule := range rules {
+		if rule.Protocol != types.RuleProtocolAny && rule.Protocol != types.RuleProtocolTCP {
+			continue
+		}
+
+		if !portRangeContains(rule.PortRange, port) {
+			continue
+		}
+
+		matches, err := addressMatches(rule.Address, addr, addressSpace, serviceTags)
+		if err != nil {
+			return false, err
+		}
+
+		if matches {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func portRangeContains(portRange string, port int) bool {
+	if portRange == "*" {
+		return true
+	}
+
+	from, to, found := strings.Cut(portRange, "-")
+	if !found {
+		to = from
+	}
+
+	fromPort, err := strconv.Atoi(from)
+	if err != nil {
+		return false
+	}
+
+	toPort, err := strconv.Atoi(to)
+	if err != nil {
+		return false
+	}
+
+	return port >= fromPort && port <= toPort
+}
+
+// addressMatches reports whether the rule address, which is an IP address, a CIDR block
+// or a service tag, contains the address.
+func addressMatches(address string, addr netip.Addr, addressSpace []netip.Prefix, serviceTags func(string) ([]netip.Prefix, error)) (bool, error) {
+	if address == "*" {
+		return true, nil
+	}
+
+	if ruleAddr, err := netip.ParseAddr(address); err == nil {
+		return ruleAddr == addr, nil
+	}
+
+	if prefix, err := netip.ParsePrefix(address); err == nil {
+		return prefix.Contains(addr), nil
+	}
+
+	var prefixes []netip.Prefix
+	switch {
+	case strings.EqualFold(address, serviceTagInternet):
+		return isInternetAddress(addr), nil
+	case strings.EqualFold(address, serviceTagVirtualNetwork):
+		prefixes = addressSpace
+	default:
+		var err error
+		prefixes, err = serviceTags(address)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// isInternetAddress reports whether the address is outside of the private, loopback,
+// link-local and shared address ranges, which the Internet service tag does not match.
+func isInternetAddress(addr netip.Addr) bool {
+	return !addr.IsPrivate() && !addr.IsLoopback() && !addr.IsLinkLocalUnicast() && !addr.IsUnspecified() &&
+		!carrierGradeNATPrefix.Contains(addr)
+}
+
+// newServiceTagResolver returns a function that looks up the IPv4 prefixes of a service
+// tag. The service tags of the region are only listed on first use.
+func newServiceTagResolver(region string, clients *ClientFactory) func(string) ([]netip.Prefix, error) {
+	var serviceTags map[string][]netip.Prefix
+
+	return func(name string) ([]netip.Prefix, error) {
+		if serviceTags == nil {
+			resp, err := clients.serviceTags.List(context.Background(), region, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list service tags: %w", err)
+			}
+
+			serviceTags = map[string][]netip.Prefix{}
+			for _, tag := range resp.Values {
+				if tag.Name == nil || tag.Properties == nil {
+					continue
+				}
+
+				prefixes := []netip.Prefix{}
+				for _, addressPrefix := range tag.Properties.AddressPrefixes {
+					prefix, err := netip.ParsePrefix(*addressPrefix)
+					if err != nil || !prefix.Addr().Is4() {
+						continue
+					}
+					prefixes = append(prefixes, prefix)
+				}
+				serviceTags[strings.ToLower(*tag.Name)] = prefixes
+			}
+		}
+
+		prefixes, ok := serviceTags[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown service tag %s", name)
+		}
+
+		return prefixes, nil
+	}
+}
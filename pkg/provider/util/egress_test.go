@@ -0,0 +1,168 @@
+package util
+
+import (
+	"fmt"
+	"net/netip"
+	"testing"
+
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+)
+
+func TestIsEgressAllowed(t *testing.T) {
+	serviceTags := func(name string) ([]netip.Prefix, error) {
+		if name == "AzureCloud" {
+			return []netip.Prefix{netip.MustParsePrefix("20.0.0.0/8")}, nil
+		}
+		return nil, fmt.Errorf("unknown service tag %s", name)
+	}
+
+	addressSpace := []netip.Prefix{netip.MustParsePrefix("10.10.0.0/16"), netip.MustParsePrefix("172.16.0.0/20")}
+
+	tests := []struct {
+		name    string
+		addr    string
+		port    int
+		rules   string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:  "CIDR block",
+			addr:  "140.82.113.4",
+			port:  443,
+			rules: "tcp:443:140.82.112.0/20",
+			want:  true,
+		},
+		{
+			name:  "Port outside of range",
+			addr:  "140.82.113.4",
+			port:  3986,
+			rules: "tcp:80-443:140.82.112.0/20",
+			want:  false,
+		},
+		{
+			name:  "UDP rule",
+			addr:  "140.82.113.4",
+			port:  443,
+			rules: "udp:443:*",
+			want:  false,
+		},
+		{
+			name:  "Service tag",
+			addr:  "20.1.2.3",
+			port:  443,
+			rules: "tcp:22:*,*:*:AzureCloud",
+			want:  true,
+		},
+		{
+			name:  "Internet excludes private addresses",
+			addr:  "10.0.0.4",
+			port:  443,
+			rules: "tcp:443:Internet",
+			want:  false,
+		},
+		{
+			name:  "Internet excludes loopback addresses",
+			addr:  "127.0.0.1",
+			port:  443,
+			rules: "tcp:443:Internet",
+			want:  false,
+		},
+		{
+			name:  "Internet excludes link-local addresses",
+			addr:  "169.254.169.254",
+			port:  443,
+			rules: "tcp:443:Internet",
+			want:  false,
+		},
+		{
+			name:  "Internet excludes shared address space",
+			addr:  "100.64.0.1",
+			port:  443,
+			rules: "tcp:443:Internet",
+			want:  false,
+		},
+		{
+			name:  "Internet excludes unspecified address",
+			addr:  "0.0.0.0",
+			port:  443,
+			rules: "tcp:443:Internet",
+			want:  false,
+		},
+		{
+			name:  "Internet",
+			addr:  "140.82.113.4",
+			port:  443,
+			rules: "tcp:443:Internet",
+			want:  true,
+		},
+		{
+			name:  "Virtual network",
+			addr:  "10.10.0.4",
+			port:  443,
+			rules: "tcp:443:VirtualNetwork",
+			want:  true,
+		},
+		{
+			name:  "Second virtual network prefix",
+			addr:  "172.16.1.4",
+			port:  443,
+			rules: "tcp:443:VirtualNetwork",
+			want:  true,
+		},
+		{
+			name:  "Outside of virtual network",
+			addr:  "10.20.0.4",
+			port:  443,
+			rules: "tcp:443:VirtualNetwork",
+			want:  false,
+		},
+		{
+			name:    "Unknown service tag",
+			addr:    "20.1.2.3",
+			port:    443,
+			rules:   "tcp:443:Unknown",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := types.ParseSecurityRules(tt.rules)
+			if err != nil {
+				t.Fatalf("ParseSecurityRules() error = %v", err)
+			}
+
+			got, err := isEgressAllowed(netip.MustParseAddr(tt.addr), tt.port, rules, addressSpace, serviceTags)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("isEgressAllowed() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("isEgressAllowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEgressEndpoint(t *testing.T) {
+	tests := []struct {
+		url  string
+		want egressEndpoint
+	}{
+		{url: "https://daytona.example.com", want: egressEndpoint{host: "daytona.example.com", port: 443}},
+		{url: "http://10.0.0.4:3128", want: egressEndpoint{host: "10.0.0.4", port: 3128}},
+		{url: "http://daytona.example.com/api", want: egressEndpoint{host: "daytona.example.com", port: 80}},
+	}
+
+	for _, tt := range tests {
+		got, err := parseEgressEndpoint(tt.url)
+		if err != nil {
+			t.Errorf("parseEgressEndpoint(%s) error = %v", tt.url, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseEgressEndpoint(%s) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package util
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+)
+
+// isNotFoundError reports whether an Azure request failed because the resource does not
+// exist.
+func isNotFoundError(err error) bool {
+	var respErr *azcore.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound
+}
+
+// isPreconditionFailedError reports whether an Azure request failed because an If-Match
+// or If-None-Match condition was not met.
+func isPreconditionFailedError(err error) bool {
+	var respErr *azcore.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusPreconditionFailed
+}
+
+// isAllocationError reports whether a virtual machine could not be allocated because
+// Azure has no capacity for its size in the region or zone.
+func isAllocationError(err error) bool {
+	var respErr *azcore.ResponseError
+	if !errors.As(err, &respErr) {
+		return false
+	}
+
+	switch respErr.ErrorCode {
+	case "AllocationFailed", "ZonalAllocationFailed", "OverconstrainedAllocationRequest",
+		"OverconstrainedZonalAllocationRequest", "SkuNotAvailable":
+		return true
+	}
+
+	return false
+}
+
+// isQuotaError reports whether a virtual machine could not be created because it would
+// exceed a vCPU quota of the subscription.
+func isQuotaError(err error) bool {
+	var respErr *azcore.ResponseError
+	if !errors.As(err, &respErr) {
+		return false
+	}
+
+	switch respErr.ErrorCode {
+	case "QuotaExceeded":
+		return true
+	case "OperationNotAllowed":
+		return strings.Contains(strings.ToLower(respErr.Error()), "quota")
+	}
+
+	return false
+}
+
+// getErrorCode returns the Azure error code of the error, or the error message if it is
+// not an Azure response error.
+func getErrorCode(err error) string {
+	var respErr *azcore.ResponseError
+	if errors.As(err, &respErr) && respErr.ErrorCode != "" {
+		return respErr.ErrorCode
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,65 @@
+package util
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+func newResponseError(statusCode int, code, message string) error {
+	return runtime.NewResponseError(&http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"error":{"code":"` + code + `","message":"` + message + `"}}`)),
+		Request:    &http.Request{Method: http.MethodPut, URL: &url.URL{Scheme: "https", Host: "management.azure.com"}},
+	})
+}
+
+func TestIsAllocationAndQuotaError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantAllocation bool
+		wantQuota      bool
+	}{
+		{
+			name:           "Zonal allocation failure",
+			err:            newResponseError(http.StatusConflict, "ZonalAllocationFailed", "Allocation failed."),
+			wantAllocation: true,
+		},
+		{
+			name:           "SKU not available",
+			err:            newResponseError(http.StatusConflict, "SkuNotAvailable", "The requested VM size is currently not available."),
+			wantAllocation: true,
+		},
+		{
+			name:      "Quota exceeded",
+			err:       newResponseError(http.StatusConflict, "OperationNotAllowed", "Operation could not be completed as it results in exceeding approved standardDSv5Family Cores quota."),
+			wantQuota: true,
+		},
+		{
+			name: "Other operation not allowed",
+			err:  newResponseError(http.StatusConflict, "OperationNotAllowed", "The VM size is not supported by the image."),
+		},
+		{
+			name: "Not an Azure error",
+			err:  errors.New("connection reset"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isAllocationError(tt.err); got != tt.wantAllocation {
+				t.Errorf("isAllocationError() = %v, want %v", got, tt.wantAllocation)
+			}
+			if got := isQuotaError(tt.err); got != tt.wantQuota {
+				t.Errorf("isQuotaError() = %v, want %v", got, tt.wantQuota)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+	"github.com/daytonaio/daytona/pkg/models"
+)
+
+// GetTargetMetadata returns the metadata of the target virtual machine and its network.
+func GetTargetMetadata(target *models.Target, opts *types.TargetOptions, clients *ClientFactory) (*types.TargetMetadata, error) {
+	vm, err := GetVirtualMachine(target, opts, clients)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := types.ToTargetMetadata(vm)
+
+	ifaceName := getResourceName(fmt.Sprintf("iface-%s", target.Id))
+	iface, err := clients.interfaces.Get(context.Background(), getResourceGroupName(opts), ifaceName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if iface.Properties != nil && iface.Properties.EnableAcceleratedNetworking != nil {
+		metadata.AcceleratedNetworking = *iface.Properties.EnableAcceleratedNetworking
+	}
+
+	if opts.HasPublicIP() {
+		publicIP, err := getPublicIPAddress(target.Id, opts, clients)
+		if err != nil {
+			return nil, err
+		}
+
+		if publicIP.Properties != nil && publicIP.Properties.IPAddress != nil {
+			metadata.PublicIPAddress = *publicIP.Properties.IPAddress
+		}
+
+		if publicIP.Properties != nil && publicIP.Properties.DNSSettings != nil && publicIP.Properties.DNSSettings.Fqdn != nil {
+			metadata.FQDN = *publicIP.Properties.DNSSettings.Fqdn
+		}
+	}
+
+	if opts.HasNATGateway() {
+		natGatewayId := opts.NATGatewayId
+		if natGatewayId == "" {
+			resp, err := clients.natGateways.Get(context.Background(), getResourceGroupName(opts), getNATGatewayName(target.Id, opts), nil)
+			if err != nil {
+				return nil, err
+			}
+			natGatewayId = *resp.ID
+		}
+
+		metadata.EgressIPAddresses, err = getNATGatewayEgressIPs(natGatewayId, clients)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.PrivateDNSZoneId != "" {
+		metadata.PrivateDNSName, err = getPrivateDNSName(target, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &metadata, nil
+}
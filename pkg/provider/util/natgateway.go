@@ -0,0 +1,205 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+)
+
+// getNATGatewayName returns the name of the NAT gateway created for the target subnet.
+// Subnets in a shared virtual network share one NAT gateway.
+func getNATGatewayName(targetId string, opts *types.TargetOptions) string {
+	if opts.NetworkMode == types.NetworkModeShared {
+		return getResourceName(fmt.Sprintf("natgw-shared-%s", strings.ToLower(opts.Region)))
+	}
+
+	return getResourceName(fmt.Sprintf("natgw-%s", targetId))
+}
+
+// getSubnetNATGatewayId returns the ID of the NAT gateway to attach to the target subnet,
+// creating it if needed, or an empty string if the subnet has no NAT gateway.
+func getSubnetNATGatewayId(targetId, resourceGroupName string, opts *types.TargetOptions, clients *ClientFactory) (string, error) {
+	if opts.NATGatewayId != "" {
+		return opts.NATGatewayId, nil
+	}
+
+	if opts.NATGateway != types.NATGatewayCreate {
+		return "", nil
+	}
+
+	natGateway, err := createNATGateway(targetId, resourceGroupName, opts, clients)
+	if err != nil {
+		return "", fmt.Errorf("cannot create NAT gateway: %+v", err)
+	}
+
+	return *natGateway.ID, nil
+}
+
+// createNATGateway creates a NAT gateway with a static public IP address. If the NAT
+// gateway already exists, it returns the existing NAT gateway.
+func createNATGateway(targetId, resourceGroupName string, opts *types.TargetOptions, clients *ClientFactory) (*armnetwork.NatGateway, error) {
+	natGatewayName := getNATGatewayName(targetId, opts)
+
+	natGatewayResp, err := clients.natGateways.Get(context.Background(), resourceGroupName, natGatewayName, nil)
+	if err == nil {
+		return &natGatewayResp.NatGateway, nil
+	}
+
+	ipPollerResp, err := clients.publicIPAddresses.BeginCreateOrUpdate(
+		context.Background(),
+		resourceGroupName,
+		natGatewayName+"-ip",
+		armnetwork.PublicIPAddress{
+			Location: to.Ptr(opts.Region),
+			SKU: &armnetwork.PublicIPAddressSKU{
+				Name: to.Ptr(armnetwork.PublicIPAddressSKUNameStandard),
+			},
+			Properties: &armnetwork.PublicIPAddressPropertiesFormat{
+				PublicIPAllocationMethod: to.Ptr(armnetwork.IPAllocationMethodStatic),
+				PublicIPAddressVersion:   to.Ptr(armnetwork.IPVersionIPv4),
+			},
+		}, nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ipResp, err := ipPollerResp.PollUntilDone(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	pollerResp, err := clients.natGateways.BeginCreateOrUpdate(
+		context.Background(),
+		resourceGroupName,
+		natGatewayName,
+		armnetwork.NatGateway{
+			Location: to.Ptr(opts.Region),
+			SKU: &armnetwork.NatGatewaySKU{
+				Name: to.Ptr(armnetwork.NatGatewaySKUNameStandard),
+			},
+			Properties: &armnetwork.NatGatewayPropertiesFormat{
+				IdleTimeoutInMinutes: to.Ptr[int32](4),
+				PublicIPAddresses: []*armnetwork.SubResource{
+					{
+						ID: ipResp.ID,
+					},
+				},
+			},
+		}, nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := pollerResp.PollUntilDone(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.NatGateway, nil
+}
+
+// getNATGatewayEgressIPs returns the public IP addresses used by the NAT gateway for
+// outbound traffic.
+func getNATGatewayEgressIPs(natGatewayId string, clients *ClientFactory) ([]string, error) {
+	id, err := arm.ParseResourceID(natGatewayId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NAT gateway id %s: %w", natGatewayId, err)
+	}
+
+	natGatewaysClient, err := clients.getNatGatewaysClient(id.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	natGatewayResp, err := natGatewaysClient.Get(context.Background(), id.ResourceGroupName, id.Name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	egressIPs := []string{}
+	if natGatewayResp.Properties == nil {
+		return egressIPs, nil
+	}
+
+	for _, publicIPRef := range natGatewayResp.Properties.PublicIPAddresses {
+		if publicIPRef.ID == nil {
+			continue
+		}
+
+		publicIPId, err := arm.ParseResourceID(*publicIPRef.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		publicIPClient, err := clients.getPublicIPAddressesClient(publicIPId.SubscriptionID)
+		if err != nil {
+			return nil, err
+		}
+
+		publicIPResp, err := publicIPClient.Get(context.Background(), publicIPId.ResourceGroupName, publicIPId.Name, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if publicIPResp.Properties != nil && publicIPResp.Properties.IPAddress != nil {
+			egressIPs = append(egressIPs, *publicIPResp.Properties.IPAddress)
+		}
+	}
+
+	return egressIPs, nil
+}
+
+// getOutboundWarning returns a warning if the target may end up with no outbound path
+// to the internet, which the bootstrap script needs to install Docker and the Daytona agent.
+func getOutboundWarning(opts *types.TargetOptions, clients *ClientFactory) (string, error) {
+	if opts.HasPublicIP() || opts.HasNATGateway() {
+		return "", nil
+	}
+
+	if opts.SubnetId != "" {
+		_, subnet, err := getExistingSubnet(opts.SubnetId, clients)
+		if err != nil {
+			return "", err
+		}
+
+		if subnet != nil && subnet.Properties != nil && (subnet.Properties.NatGateway != nil || subnet.Properties.RouteTable != nil) {
+			return "", nil
+		}
+
+		return "the existing subnet has no NAT gateway or route table, so the target may have no outbound internet access. " +
+			"Make sure the subnet has an outbound path or set Public IP", nil
+	}
+
+	return "the target has no NAT gateway or public IP address and relies on Azure default outbound access, " +
+		"which is being retired for new virtual networks. Set NAT Gateway to Create if the target cannot reach the internet", nil
+}
+
+// deleteNATGateway deletes the NAT gateway created for the target subnet and its public IP address.
+func deleteNATGateway(natGatewayName string, opts *types.TargetOptions, clients *ClientFactory) error {
+	resourceGroupName := getResourceGroupName(opts)
+
+	pollerResp, err := clients.natGateways.BeginDelete(context.Background(), resourceGroupName, natGatewayName, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = pollerResp.PollUntilDone(context.Background(), nil)
+	if err != nil {
+		return err
+	}
+
+	ipPollerResp, err := clients.publicIPAddresses.BeginDelete(context.Background(), resourceGroupName, natGatewayName+"-ip", nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = ipPollerResp.PollUntilDone(context.Background(), nil)
+	return err
+}
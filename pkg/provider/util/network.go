@@ -0,0 +1,319 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+)
+
+const (
+	sharedSubnetPrefixLength = 24
+	subnetAllocationAttempts = 5
+)
+
+// sharedNetworkMutex serializes changes to shared virtual networks within this process.
+// Conflicts with other processes using the same network are retried.
+var sharedNetworkMutex sync.Mutex
+
+// getVirtualNetworkName returns the name of the virtual network used by the target.
+func getVirtualNetworkName(targetId string, opts *types.TargetOptions) string {
+	if opts.NetworkMode == types.NetworkModeShared {
+		return getResourceName(fmt.Sprintf("vnet-shared-%s", strings.ToLower(opts.Region)))
+	}
+
+	return getResourceName(fmt.Sprintf("vnet-%s", targetId))
+}
+
+// getExistingSubnet returns the existing subnet with the given resource ID and its virtual
+// network. The subnet is nil if the virtual network exists but has no such subnet.
+func getExistingSubnet(id string, clients *ClientFactory) (*armnetwork.VirtualNetwork, *armnetwork.Subnet, error) {
+	subnetId, err := arm.ParseResourceID(id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid subnet id %s: %w", id, err)
+	}
+
+	vNetId := subnetId.Parent
+	vnetClient, err := clients.getVirtualNetworksClient(vNetId.SubscriptionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := vnetClient.Get(context.Background(), vNetId.ResourceGroupName, vNetId.Name, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get virtual network %s: %w", vNetId.Name, err)
+	}
+
+	if resp.Properties != nil {
+		for _, subnet := range resp.Properties.Subnets {
+			if subnet.Name != nil && strings.EqualFold(*subnet.Name, subnetId.Name) {
+				return &resp.VirtualNetwork, subnet, nil
+			}
+		}
+	}
+
+	return &resp.VirtualNetwork, nil, nil
+}
+
+// createSharedSubnet creates the shared virtual network if needed and adds a subnet for
+// the target in a free address block.
+func createSharedSubnet(targetId, resourceGroupName string, opts *types.TargetOptions, clients *ClientFactory) (*armnetwork.Subnet, error) {
+	sharedNetworkMutex.Lock()
+	defer sharedNetworkMutex.Unlock()
+
+	vNet, err := createVirtualNetwork(targetId, resourceGroupName, opts, clients)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create virtual network: %+v", err)
+	}
+
+	natGatewayId, err := getSubnetNATGatewayId(targetId, resourceGroupName, opts, clients)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; attempt <= subnetAllocationAttempts; attempt++ {
+		var addressPrefix string
+		addressPrefix, err = allocateSubnetPrefix(resourceGroupName, *vNet.Name, clients)
+		if err != nil {
+			return nil, err
+		}
+
+		var subnet *armnetwork.Subnet
+		subnet, err = createSubnet(targetId, resourceGroupName, *vNet.Name, addressPrefix, natGatewayId, clients)
+		if err == nil {
+			return subnet, nil
+		}
+
+		if !isNetworkConflictError(err) {
+			return nil, err
+		}
+
+		time.Sleep(time.Duration(attempt) * 2 * time.Second)
+	}
+
+	return nil, fmt.Errorf("failed to allocate a subnet after %d attempts: %w", subnetAllocationAttempts, err)
+}
+
+// allocateSubnetPrefix returns a free address block in the virtual network.
+func allocateSubnetPrefix(resourceGroupName, vNetName string, clients *ClientFactory) (string, error) {
+	resp, err := clients.virtualNetworks.Get(context.Background(), resourceGroupName, vNetName, nil)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.Properties == nil || resp.Properties.AddressSpace == nil {
+		return "", fmt.Errorf("virtual network %s has no address space", vNetName)
+	}
+
+	usedPrefixes := []string{}
+	for _, subnet := range resp.Properties.Subnets {
+		if subnet.Properties == nil {
+			continue
+		}
+		if subnet.Properties.AddressPrefix != nil {
+			usedPrefixes = append(usedPrefixes, *subnet.Properties.AddressPrefix)
+		}
+		for _, prefix := range subnet.Properties.AddressPrefixes {
+			if prefix != nil {
+				usedPrefixes = append(usedPrefixes, *prefix)
+			}
+		}
+	}
+
+	for _, addressSpace := range resp.Properties.AddressSpace.AddressPrefixes {
+		if addressSpace == nil {
+			continue
+		}
+
+		prefix, err := getFreeSubnetPrefix(*addressSpace, usedPrefixes, sharedSubnetPrefixLength)
+		if err == nil {
+			return prefix, nil
+		}
+	}
+
+	return "", fmt.Errorf("virtual network %s has no free /%d address block", vNetName, sharedSubnetPrefixLength)
+}
+
+// getFreeSubnetPrefix returns the first block of the given prefix length in the address
+// space that does not overlap any of the used prefixes.
+func getFreeSubnetPrefix(addressSpace string, usedPrefixes []string, prefixLength int) (string, error) {
+	space, err := netip.ParsePrefix(addressSpace)
+	if err != nil {
+		return "", fmt.Errorf("invalid address space %s: %w", addressSpace, err)
+	}
+	space = space.Masked()
+
+	if !space.Addr().Is4() || prefixLength < space.Bits() || prefixLength > 32 {
+		return "", fmt.Errorf("cannot allocate a /%d block in %s", prefixLength, addressSpace)
+	}
+
+	used := []netip.Prefix{}
+	for _, usedPrefix := range usedPrefixes {
+		prefix, err := netip.ParsePrefix(usedPrefix)
+		if err != nil {
+			return "", fmt.Errorf("invalid subnet prefix %s: %w", usedPrefix, err)
+		}
+		used = append(used, prefix)
+	}
+
+	start := space.Addr().As4()
+	first := uint64(start[0])<<24 | uint64(start[1])<<16 | uint64(start[2])<<8 | uint64(start[3])
+	blockSize := uint64(1) << (32 - prefixLength)
+	blocks := uint64(1) << (prefixLength - space.Bits())
+
+	for i := uint64(0); i < blocks; i++ {
+		address := first + i*blockSize
+		candidate := netip.PrefixFrom(netip.AddrFrom4([4]byte{byte(address >> 24), byte(address >> 16), byte(address >> 8), byte(address)}), prefixLength)
+
+		overlaps := false
+		for _, prefix := range used {
+			if candidate.Overlaps(prefix) {
+				overlaps = true
+				break
+			}
+		}
+
+		if !overlaps {
+			return candidate.String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("no free /%d block in %s", prefixLength, addressSpace)
+}
+
+// deleteSharedSubnet deletes the subnet of the target from the shared virtual network,
+// and deletes the virtual network and its NAT gateway once its last subnet is removed.
+func deleteSharedSubnet(targetId string, opts *types.TargetOptions, clients *ClientFactory) error {
+	sharedNetworkMutex.Lock()
+	defer sharedNetworkMutex.Unlock()
+
+	vNetName := getVirtualNetworkName(targetId, opts)
+	subnetName := getResourceName(fmt.Sprintf("subnet-%s", targetId))
+
+	err := deleteSubnet(vNetName, subnetName, opts, clients)
+	if err != nil {
+		return fmt.Errorf("cannot delete subnet: %+v", err)
+	}
+
+	resp, err := clients.virtualNetworks.Get(context.Background(), getResourceGroupName(opts), vNetName, nil)
+	if err != nil {
+		return fmt.Errorf("cannot get virtual network: %+v", err)
+	}
+
+	if resp.Properties != nil && len(resp.Properties.Subnets) > 0 {
+		return nil
+	}
+
+	err = deleteVirtualNetwork(vNetName, opts, clients)
+	if err != nil {
+		// Another process may have added a subnet in the meantime
+		if isNetworkConflictError(err) {
+			return nil
+		}
+		return fmt.Errorf("cannot delete virtual network: %+v", err)
+	}
+
+	if opts.NATGateway == types.NATGatewayCreate {
+		err = deleteNATGateway(getNATGatewayName(targetId, opts), opts, clients)
+		if err != nil {
+			return fmt.Errorf("cannot delete NAT gateway: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+// validateAddressSpace checks that the address space of the target virtual network does not
+// overlap the virtual networks in the resource group or the networks peered to them. The
+// address spaces of virtual networks created by Daytona are not checked, as they are never
+// connected to each other, but their peerings are.
+func validateAddressSpace(opts *types.TargetOptions, clients *ClientFactory) error {
+	addressSpace := opts.GetAddressSpace()
+	resourceGroupName := getResourceGroupName(opts)
+
+	pager := clients.virtualNetworks.NewListPager(resourceGroupName, nil)
+	for pager.More() {
+		page, err := pager.NextPage(context.Background())
+		if err != nil {
+			var respErr *azcore.ResponseError
+			// The resource group is created later
+			if errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound {
+				return nil
+			}
+			return fmt.Errorf("failed to list virtual networks in %s: %w", resourceGroupName, err)
+		}
+
+		for _, vNet := range page.Value {
+			if vNet.Name == nil || vNet.Properties == nil {
+				continue
+			}
+
+			if !strings.HasPrefix(*vNet.Name, getResourceName("vnet-")) && vNet.Properties.AddressSpace != nil {
+				overlap, ok := findOverlappingPrefix(addressSpace, vNet.Properties.AddressSpace.AddressPrefixes)
+				if ok {
+					return fmt.Errorf("address space %s overlaps %s of virtual network %s", addressSpace, overlap, *vNet.Name)
+				}
+			}
+
+			for _, peering := range vNet.Properties.VirtualNetworkPeerings {
+				if peering.Properties == nil || peering.Properties.RemoteAddressSpace == nil {
+					continue
+				}
+
+				overlap, ok := findOverlappingPrefix(addressSpace, peering.Properties.RemoteAddressSpace.AddressPrefixes)
+				if ok {
+					return fmt.Errorf("address space %s overlaps %s of the network peered to virtual network %s", addressSpace, overlap, *vNet.Name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// findOverlappingPrefix returns the first of the prefixes that overlaps the address space.
+// Prefixes that cannot be parsed are skipped.
+func findOverlappingPrefix(addressSpace string, prefixes []*string) (string, bool) {
+	space, err := netip.ParsePrefix(addressSpace)
+	if err != nil {
+		return "", false
+	}
+
+	for _, prefix := range prefixes {
+		if prefix == nil {
+			continue
+		}
+
+		parsed, err := netip.ParsePrefix(*prefix)
+		if err == nil && parsed.Overlaps(space) {
+			return *prefix, true
+		}
+	}
+
+	return "", false
+}
+
+// isNetworkConflictError reports whether a network operation failed because of a
+// concurrent change to the same virtual network.
+func isNetworkConflictError(err error) bool {
+	var respErr *azcore.ResponseError
+	if !errors.As(err, &respErr) {
+		return false
+	}
+
+	switch respErr.ErrorCode {
+	case "NetcfgSubnetRangesOverlap", "InUseSubnetCannotBeDeleted", "AnotherOperationInProgress", "RetryableError":
+		return true
+	}
+
+	return respErr.StatusCode == http.StatusConflict
+}
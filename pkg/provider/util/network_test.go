@@ -0,0 +1,68 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+)
+
+func TestGetFreeSubnetPrefix(t *testing.T) {
+	tests := []struct {
+		name         string
+		addressSpace string
+		usedPrefixes []string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "Empty address space",
+			addressSpace: "10.10.0.0/16",
+			want:         "10.10.0.0/24",
+		},
+		{
+			name:         "Skips used and overlapping blocks",
+			addressSpace: "10.10.0.0/16",
+			usedPrefixes: []string{"10.10.0.0/24", "10.10.1.128/25", "10.10.3.0/24"},
+			want:         "10.10.2.0/24",
+		},
+		{
+			name:         "Address space exhausted",
+			addressSpace: "10.10.0.0/23",
+			usedPrefixes: []string{"10.10.0.0/24", "10.10.1.0/24"},
+			wantErr:      true,
+		},
+		{
+			name:         "Invalid used prefix",
+			addressSpace: "10.10.0.0/16",
+			usedPrefixes: []string{"10.10.0.0"},
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getFreeSubnetPrefix(tt.addressSpace, tt.usedPrefixes, sharedSubnetPrefixLength)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getFreeSubnetPrefix() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("getFreeSubnetPrefix() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindOverlappingPrefix(t *testing.T) {
+	prefixes := []*string{to.Ptr("192.168.0.0/24"), nil, to.Ptr("invalid"), to.Ptr("10.0.0.0/8")}
+
+	overlap, ok := findOverlappingPrefix("10.10.0.0/16", prefixes)
+	if !ok || overlap != "10.0.0.0/8" {
+		t.Errorf("findOverlappingPrefix() = %v, %v, want 10.0.0.0/8, true", overlap, ok)
+	}
+
+	overlap, ok = findOverlappingPrefix("172.16.0.0/12", prefixes)
+	if ok {
+		t.Errorf("findOverlappingPrefix() = %v, %v, want no overlap", overlap, ok)
+	}
+}
@@ -0,0 +1,163 @@
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+)
+
+const (
+	customRulesPriority     = 100
+	tailscalePriority       = 3000
+	denyInboundPriority     = 4096
+	denyOutboundPriority    = 4096
+	dnsServersPriority      = 3100
+	dnsPort                 = "53"
+	tailscaleDirectPort     = "41641"
+	customRulesPriorityStep = 10
+)
+
+// createNetworkSecurityGroup creates the network security group of the target. It denies
+// all inbound traffic, including traffic from the virtual network, except for direct
+// Tailscale connections and the custom rules from the target options. With the AllowList
+// egress policy it also denies all outbound traffic except the egress allow list and the
+// custom DNS servers.
+func createNetworkSecurityGroup(targetId, resourceGroupName string, opts *types.TargetOptions, clients *ClientFactory) (*armnetwork.SecurityGroup, error) {
+	inboundRules, err := types.ParseSecurityRules(opts.InboundRules)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []*armnetwork.SecurityRule{}
+	for i, rule := range inboundRules {
+		rules = append(rules, newInboundSecurityRule(
+			fmt.Sprintf("daytona-allow-%d", i+1),
+			int32(customRulesPriority+i*customRulesPriorityStep),
+			armnetwork.SecurityRuleAccessAllow,
+			armnetwork.SecurityRuleProtocol(rule.Protocol),
+			rule.PortRange,
+			rule.Address,
+		))
+	}
+
+	rules = append(rules,
+		newInboundSecurityRule("daytona-allow-tailscale", tailscalePriority, armnetwork.SecurityRuleAccessAllow,
+			armnetwork.SecurityRuleProtocolUDP, tailscaleDirectPort, "*"),
+		newInboundSecurityRule("daytona-deny-inbound", denyInboundPriority, armnetwork.SecurityRuleAccessDeny,
+			armnetwork.SecurityRuleProtocolAsterisk, "*", "*"),
+	)
+
+	if opts.EgressPolicy == types.EgressPolicyAllowList {
+		outboundRules, err := getOutboundSecurityRules(opts)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, outboundRules...)
+	}
+
+	nsgName := getResourceName(fmt.Sprintf("nsg-%s", targetId))
+	pollerResp, err := clients.securityGroups.BeginCreateOrUpdate(
+		context.Background(),
+		resourceGroupName,
+		nsgName,
+		armnetwork.SecurityGroup{
+			Location: to.Ptr(opts.Region),
+			Properties: &armnetwork.SecurityGroupPropertiesFormat{
+				SecurityRules: rules,
+			},
+		}, nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := pollerResp.PollUntilDone(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.SecurityGroup, nil
+}
+
+// getOutboundSecurityRules returns the outbound rules of the AllowList egress policy.
+// Traffic to the Azure DNS and metadata endpoints is not filtered by network security
+// groups, but custom DNS servers have to be allowed explicitly.
+func getOutboundSecurityRules(opts *types.TargetOptions) ([]*armnetwork.SecurityRule, error) {
+	allowList, err := types.ParseSecurityRules(opts.EgressAllowList)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []*armnetwork.SecurityRule{}
+	for i, rule := range allowList {
+		rules = append(rules, newOutboundSecurityRule(
+			fmt.Sprintf("daytona-allow-egress-%d", i+1),
+			int32(customRulesPriority+i*customRulesPriorityStep),
+			armnetwork.SecurityRuleAccessAllow,
+			armnetwork.SecurityRuleProtocol(rule.Protocol),
+			rule.PortRange,
+			rule.Address,
+		))
+	}
+
+	for i, dnsServer := range opts.GetDNSServers() {
+		rules = append(rules, newOutboundSecurityRule(
+			fmt.Sprintf("daytona-allow-dns-%d", i+1),
+			int32(dnsServersPriority+i*customRulesPriorityStep),
+			armnetwork.SecurityRuleAccessAllow,
+			armnetwork.SecurityRuleProtocolAsterisk,
+			dnsPort,
+			dnsServer,
+		))
+	}
+
+	rules = append(rules, newOutboundSecurityRule("daytona-deny-outbound", denyOutboundPriority, armnetwork.SecurityRuleAccessDeny,
+		armnetwork.SecurityRuleProtocolAsterisk, "*", "*"))
+
+	return rules, nil
+}
+
+// newInboundSecurityRule returns an inbound rule for traffic from the source to any
+// address of the target.
+func newInboundSecurityRule(name string, priority int32, access armnetwork.SecurityRuleAccess, protocol armnetwork.SecurityRuleProtocol, portRange, source string) *armnetwork.SecurityRule {
+	return newSecurityRule(name, armnetwork.SecurityRuleDirectionInbound, priority, access, protocol, portRange, source, "*")
+}
+
+// newOutboundSecurityRule returns an outbound rule for traffic from any address of the
+// target to the destination.
+func newOutboundSecurityRule(name string, priority int32, access armnetwork.SecurityRuleAccess, protocol armnetwork.SecurityRuleProtocol, portRange, destination string) *armnetwork.SecurityRule {
+	return newSecurityRule(name, armnetwork.SecurityRuleDirectionOutbound, priority, access, protocol, portRange, "*", destination)
+}
+
+func newSecurityRule(name string, direction armnetwork.SecurityRuleDirection, priority int32, access armnetwork.SecurityRuleAccess, protocol armnetwork.SecurityRuleProtocol, portRange, source, destination string) *armnetwork.SecurityRule {
+	return &armnetwork.SecurityRule{
+		Name: to.Ptr(name),
+		Properties: &armnetwork.SecurityRulePropertiesFormat{
+			Direction:                to.Ptr(direction),
+			Access:                   to.Ptr(access),
+			Priority:                 to.Ptr(priority),
+			Protocol:                 to.Ptr(protocol),
+			SourceAddressPrefix:      to.Ptr(source),
+			SourcePortRange:          to.Ptr("*"),
+			DestinationAddressPrefix: to.Ptr(destination),
+			DestinationPortRange:     to.Ptr(portRange),
+		},
+	}
+}
+
+// deleteNetworkSecurityGroup deletes the network security group of the target.
+func deleteNetworkSecurityGroup(targetId string, opts *types.TargetOptions, clients *ClientFactory) error {
+	resourceGroupName := getResourceGroupName(opts)
+	nsgName := getResourceName(fmt.Sprintf("nsg-%s", targetId))
+
+	pollerResp, err := clients.securityGroups.BeginDelete(context.Background(), resourceGroupName, nsgName, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = pollerResp.PollUntilDone(context.Background(), nil)
+	return err
+}
@@ -0,0 +1,119 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+)
+
+const (
+	caBundlePath = "/usr/local/share/ca-certificates/daytona-ca-bundle.crt"
+)
+
+// getProxyEnvVars returns the proxy environment variables of the target. They are set in
+// both upper and lower case, as tools differ in which one they read.
+func getProxyEnvVars(opts *types.TargetOptions) map[string]string {
+	envVars := map[string]string{}
+	if !opts.HasProxy() {
+		return envVars
+	}
+
+	if opts.HTTPProxy != "" {
+		envVars["HTTP_PROXY"] = opts.HTTPProxy
+		envVars["http_proxy"] = opts.HTTPProxy
+	}
+
+	if opts.HTTPSProxy != "" {
+		envVars["HTTPS_PROXY"] = opts.HTTPSProxy
+		envVars["https_proxy"] = opts.HTTPSProxy
+	}
+
+	envVars["NO_PROXY"] = opts.GetNoProxy()
+	envVars["no_proxy"] = opts.GetNoProxy()
+
+	return envVars
+}
+
+// getProxyScript returns the bootstrap script section that installs the CA bundle and
+// exports the proxy environment variables for the commands that follow it.
+func getProxyScript(opts *types.TargetOptions) (string, error) {
+	script := ""
+
+	if opts.CABundle != "" {
+		caBundle, err := loadCABundle(opts.CABundle)
+		if err != nil {
+			return "", err
+		}
+
+		script += fmt.Sprintf(`# Trust the additional CA certificates
+mkdir -p $(dirname %[1]s)
+cat > %[1]s <<'DAYTONA_CA_EOF'
+%[2]s
+DAYTONA_CA_EOF
+if [ -d /etc/pki/ca-trust/source/anchors ]; then
+	cp %[1]s /etc/pki/ca-trust/source/anchors/
+	update-ca-trust
+else
+	update-ca-certificates
+fi
+
+`, caBundlePath, strings.TrimSpace(string(caBundle)))
+	}
+
+	envVars := getProxyEnvVars(opts)
+	if len(envVars) > 0 {
+		script += "# Proxy configuration\n"
+		for _, name := range sortedKeys(envVars) {
+			script += fmt.Sprintf("export %s=%s\n", name, shellQuote(envVars[name]))
+		}
+		script += "\n"
+	}
+
+	return script, nil
+}
+
+// getSystemdEnvironment returns the systemd Environment lines that set the environment
+// variables, for the Docker daemon drop-in and the agent unit.
+func getSystemdEnvironment(envVars map[string]string) string {
+	config := ""
+
+	for _, name := range sortedKeys(envVars) {
+		config += "Environment=" + systemdQuote(name+"="+envVars[name]) + "\n"
+	}
+
+	return config
+}
+
+// loadCABundle returns the PEM encoded CA bundle, which is either set inline or read
+// from the given path.
+func loadCABundle(caBundle string) ([]byte, error) {
+	data := []byte(caBundle)
+	if !strings.HasPrefix(strings.TrimSpace(caBundle), "-----BEGIN") {
+		var err error
+		data, err = os.ReadFile(caBundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+	}
+
+	if !strings.Contains(string(data), "-----BEGIN CERTIFICATE-----") {
+		return nil, fmt.Errorf("CA bundle contains no PEM encoded certificates")
+	}
+
+	return data, nil
+}
+
+// systemdQuote quotes the value for use as a single word in a systemd unit file. The %
+// specifier character is escaped as well, and newlines are escaped so the value stays on
+// one line.
+func systemdQuote(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "%", "%%", "\n", `\n`, "\r", `\r`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// shellQuote quotes the value for use as a single shell word.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
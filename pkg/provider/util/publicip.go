@@ -0,0 +1,73 @@
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork"
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+)
+
+// createPublicIPAddress creates the static Standard SKU public IP address of the target.
+func createPublicIPAddress(targetId, resourceGroupName string, opts *types.TargetOptions, clients *ClientFactory) (*armnetwork.PublicIPAddress, error) {
+	properties := &armnetwork.PublicIPAddressPropertiesFormat{
+		PublicIPAllocationMethod: to.Ptr(armnetwork.IPAllocationMethodStatic),
+		PublicIPAddressVersion:   to.Ptr(armnetwork.IPVersionIPv4),
+	}
+
+	if opts.PublicIPDNSLabel != "" {
+		properties.DNSSettings = &armnetwork.PublicIPAddressDNSSettings{
+			DomainNameLabel: to.Ptr(opts.PublicIPDNSLabel),
+		}
+	}
+
+	publicIPName := getResourceName(fmt.Sprintf("ip-%s", targetId))
+	pollerResp, err := clients.publicIPAddresses.BeginCreateOrUpdate(
+		context.Background(),
+		resourceGroupName,
+		publicIPName,
+		armnetwork.PublicIPAddress{
+			Location: to.Ptr(opts.Region),
+			SKU: &armnetwork.PublicIPAddressSKU{
+				Name: to.Ptr(armnetwork.PublicIPAddressSKUNameStandard),
+			},
+			Properties: properties,
+		}, nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := pollerResp.PollUntilDone(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.PublicIPAddress, nil
+}
+
+// getPublicIPAddress returns the public IP address of the target.
+func getPublicIPAddress(targetId string, opts *types.TargetOptions, clients *ClientFactory) (*armnetwork.PublicIPAddress, error) {
+	publicIPName := getResourceName(fmt.Sprintf("ip-%s", targetId))
+
+	resp, err := clients.publicIPAddresses.Get(context.Background(), getResourceGroupName(opts), publicIPName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.PublicIPAddress, nil
+}
+
+// deletePublicIPAddress deletes the public IP address of the target.
+func deletePublicIPAddress(targetId string, opts *types.TargetOptions, clients *ClientFactory) error {
+	publicIPName := getResourceName(fmt.Sprintf("ip-%s", targetId))
+
+	pollerResp, err := clients.publicIPAddresses.BeginDelete(context.Background(), getResourceGroupName(opts), publicIPName, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = pollerResp.PollUntilDone(context.Background(), nil)
+	return err
+}
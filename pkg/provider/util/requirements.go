@@ -0,0 +1,152 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+	"github.com/daytonaio/daytona/pkg/provider"
+)
+
+var requiredResourceProviders = []string{"Microsoft.Compute", "Microsoft.Network"}
+
+// CheckRequirements runs preflight checks against the subscription in the target options
+// and reports whether it is ready for creating targets.
+func CheckRequirements(opts *types.TargetOptions, clients *ClientFactory) []provider.RequirementStatus {
+	results := []provider.RequirementStatus{}
+
+	err := checkCredentials(clients)
+	results = append(results, toRequirementStatus("Azure credentials", err))
+	if err != nil {
+		return results
+	}
+
+	err = checkSubscription(clients)
+	results = append(results, toRequirementStatus(fmt.Sprintf("Azure subscription %s", opts.SubscriptionId), err))
+	if err != nil {
+		return results
+	}
+
+	for _, namespace := range requiredResourceProviders {
+		err = checkResourceProvider(namespace, clients)
+		results = append(results, toRequirementStatus(fmt.Sprintf("%s resource provider registered", namespace), err))
+	}
+
+	err = checkVCPUQuota(opts, clients)
+	results = append(results, toRequirementStatus(fmt.Sprintf("vCPU quota for %s in %s", opts.VMSize, opts.Region), err))
+
+	return results
+}
+
+// checkCredentials checks that the credential can get a token for Azure Resource Manager.
+func checkCredentials(clients *ClientFactory) error {
+	_, err := clients.cred.GetToken(context.Background(), policy.TokenRequestOptions{
+		Scopes: []string{clients.tokenScope},
+	})
+	return err
+}
+
+// checkSubscription checks that the subscription exists and is accessible.
+func checkSubscription(clients *ClientFactory) error {
+	pager := clients.resourceGroups.NewListPager(&armresources.ResourceGroupsClientListOptions{
+		Top: to.Ptr[int32](1),
+	})
+	_, err := pager.NextPage(context.Background())
+	return err
+}
+
+// checkResourceProvider checks that the resource provider namespace is registered in the subscription.
+func checkResourceProvider(namespace string, clients *ClientFactory) error {
+	resp, err := clients.providers.Get(context.Background(), namespace, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.RegistrationState == nil || !strings.EqualFold(*resp.RegistrationState, "Registered") {
+		state := "Unknown"
+		if resp.RegistrationState != nil {
+			state = *resp.RegistrationState
+		}
+		return fmt.Errorf("%s is %s. Register it with \"az provider register --namespace %s\"", namespace, state, namespace)
+	}
+
+	return nil
+}
+
+// checkVCPUQuota checks that the regional and VM size family vCPU quotas leave room
+// for at least one virtual machine of the configured size.
+func checkVCPUQuota(opts *types.TargetOptions, clients *ClientFactory) error {
+	skus, err := listVirtualMachineSKUs(opts.Region, clients)
+	if err != nil {
+		return err
+	}
+
+	sku := findSKU(skus, opts.VMSize)
+	if sku == nil {
+		return fmt.Errorf("VM size %s is not offered in %s", opts.VMSize, opts.Region)
+	}
+
+	vCPUs, ok := getSKUCapabilityInt(sku, "vCPUs")
+	if !ok {
+		return fmt.Errorf("cannot determine the vCPU count of %s", opts.VMSize)
+	}
+
+	family := ""
+	if sku.Family != nil {
+		family = *sku.Family
+	}
+
+	pager := clients.usage.NewListPager(opts.Region, nil)
+	for pager.More() {
+		page, err := pager.NextPage(context.Background())
+		if err != nil {
+			return err
+		}
+
+		err = checkVCPUUsage(page.Value, family, opts.VMSize, vCPUs)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkVCPUUsage checks that the regional and VM size family usages leave room for the
+// vCPUs of one virtual machine.
+func checkVCPUUsage(usages []*armcompute.Usage, family, vmSize string, vCPUs int) error {
+	for _, usage := range usages {
+		if usage.Name == nil || usage.Name.Value == nil || usage.Limit == nil || usage.CurrentValue == nil {
+			continue
+		}
+
+		name := *usage.Name.Value
+		if name != "cores" && !strings.EqualFold(name, family) {
+			continue
+		}
+
+		available := *usage.Limit - int64(*usage.CurrentValue)
+		if available < int64(vCPUs) {
+			localizedName := name
+			if usage.Name.LocalizedValue != nil {
+				localizedName = *usage.Name.LocalizedValue
+			}
+			return fmt.Errorf("%s has %d of %d vCPUs available, %s needs %d", localizedName, available, *usage.Limit, vmSize, vCPUs)
+		}
+	}
+
+	return nil
+}
+
+func toRequirementStatus(name string, err error) provider.RequirementStatus {
+	if err != nil {
+		return provider.RequirementStatus{Name: name, Met: false, Reason: err.Error()}
+	}
+
+	return provider.RequirementStatus{Name: name, Met: true}
+}
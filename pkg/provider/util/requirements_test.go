@@ -0,0 +1,67 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
+)
+
+func TestCheckVCPUUsage(t *testing.T) {
+	newUsage := func(name string, current int32, limit int64) *armcompute.Usage {
+		return &armcompute.Usage{
+			Name:         &armcompute.UsageName{Value: to.Ptr(name), LocalizedValue: to.Ptr(name + " vCPUs")},
+			CurrentValue: to.Ptr(current),
+			Limit:        to.Ptr(limit),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		usages  []*armcompute.Usage
+		vCPUs   int
+		wantErr bool
+	}{
+		{
+			name:   "Enough quota",
+			usages: []*armcompute.Usage{newUsage("cores", 10, 20), newUsage("standardDSv5Family", 0, 10)},
+			vCPUs:  4,
+		},
+		{
+			name:   "Exactly enough quota",
+			usages: []*armcompute.Usage{newUsage("cores", 16, 20), newUsage("standardDSv5Family", 6, 10)},
+			vCPUs:  4,
+		},
+		{
+			name:    "Regional quota exhausted",
+			usages:  []*armcompute.Usage{newUsage("cores", 18, 20), newUsage("standardDSv5Family", 0, 10)},
+			vCPUs:   4,
+			wantErr: true,
+		},
+		{
+			name:    "Family quota exhausted",
+			usages:  []*armcompute.Usage{newUsage("cores", 0, 20), newUsage("StandardDSv5Family", 8, 10)},
+			vCPUs:   4,
+			wantErr: true,
+		},
+		{
+			name:   "Other family quota exhausted",
+			usages: []*armcompute.Usage{newUsage("cores", 0, 20), newUsage("standardNCFamily", 0, 0)},
+			vCPUs:  4,
+		},
+		{
+			name:   "Incomplete usage",
+			usages: []*armcompute.Usage{{Name: &armcompute.UsageName{Value: to.Ptr("cores")}}},
+			vCPUs:  4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkVCPUUsage(tt.usages, "standardDSv5Family", "Standard_D4s_v5", tt.vCPUs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkVCPUUsage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+)
+
+const (
+	virtualMachinesResourceType = "virtualMachines"
+)
+
+// listVirtualMachineSKUs returns the virtual machine sizes offered in the given location.
+func listVirtualMachineSKUs(location string, clients *ClientFactory) ([]*armcompute.ResourceSKU, error) {
+	pager := clients.resourceSKUs.NewListPager(&armcompute.ResourceSKUsClientListOptions{
+		Filter: to.Ptr(fmt.Sprintf("location eq '%s'", location)),
+	})
+
+	var skus []*armcompute.ResourceSKU
+	for pager.More() {
+		page, err := pager.NextPage(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, sku := range page.Value {
+			if sku.ResourceType != nil && *sku.ResourceType == virtualMachinesResourceType {
+				skus = append(skus, sku)
+			}
+		}
+	}
+
+	return skus, nil
+}
+
+// findSKU returns the SKU with the given name, or nil if it is not in the list.
+func findSKU(skus []*armcompute.ResourceSKU, name string) *armcompute.ResourceSKU {
+	for _, sku := range skus {
+		if sku.Name != nil && strings.EqualFold(*sku.Name, name) {
+			return sku
+		}
+	}
+
+	return nil
+}
+
+// getSKUCapability returns the value of the named capability of a SKU.
+func getSKUCapability(sku *armcompute.ResourceSKU, name string) (string, bool) {
+	for _, capability := range sku.Capabilities {
+		if capability.Name != nil && capability.Value != nil && *capability.Name == name {
+			return *capability.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// getSKUCapabilityInt returns the value of the named capability of a SKU as an integer.
+func getSKUCapabilityInt(sku *armcompute.ResourceSKU, name string) (int, bool) {
+	value, ok := getSKUCapability(sku, name)
+	if !ok {
+		return 0, false
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return int(number), true
+}
+
+// getVMSizeInfo describes a VM size from its SKU capabilities. The price is taken
+// from the catalogue, as SKUs carry no pricing.
+func getVMSizeInfo(sku *armcompute.ResourceSKU) types.VMSizeInfo {
+	info, _ := types.GetVMSizeInfo(*sku.Name)
+	info.Name = *sku.Name
+
+	if vCPUs, ok := getSKUCapabilityInt(sku, "vCPUs"); ok {
+		info.VCPUs = vCPUs
+	}
+
+	if memory, ok := getSKUCapability(sku, "MemoryGB"); ok {
+		if memoryGB, err := strconv.ParseFloat(memory, 64); err == nil {
+			info.MemoryGB = memoryGB
+		}
+	}
+
+	if architecture, ok := getSKUCapability(sku, "CpuArchitectureType"); ok {
+		info.Architecture = architecture
+	}
+
+	if premiumIO, ok := getSKUCapability(sku, "PremiumIO"); ok {
+		info.PremiumStorage = strings.EqualFold(premiumIO, "True")
+	}
+
+	return info
+}
+
+// supportsAcceleratedNetworking reports whether the VM size supports accelerated networking.
+func supportsAcceleratedNetworking(sku *armcompute.ResourceSKU) bool {
+	value, ok := getSKUCapability(sku, "AcceleratedNetworkingEnabled")
+	return ok && strings.EqualFold(value, "True")
+}
+
+// supportsSpot reports whether the SKU can run as a Spot virtual machine. SKUs without
+// the capability are assumed to support it.
+func supportsSpot(sku *armcompute.ResourceSKU) bool {
+	value, ok := getSKUCapability(sku, "LowPriorityCapable")
+	return !ok || strings.EqualFold(value, "True")
+}
+
+// getSKUZones returns the sorted availability zones that offer the SKU in the location
+// and are not restricted for the subscription.
+func getSKUZones(sku *armcompute.ResourceSKU, location string) []string {
+	restricted := map[string]bool{}
+	for _, restriction := range sku.Restrictions {
+		if restriction.Type == nil || *restriction.Type != armcompute.ResourceSKURestrictionsTypeZone || restriction.RestrictionInfo == nil {
+			continue
+		}
+
+		for _, zone := range restriction.RestrictionInfo.Zones {
+			if zone != nil {
+				restricted[*zone] = true
+			}
+		}
+	}
+
+	zones := []string{}
+	for _, locationInfo := range sku.LocationInfo {
+		if locationInfo.Location == nil || !strings.EqualFold(*locationInfo.Location, location) {
+			continue
+		}
+
+		for _, zone := range locationInfo.Zones {
+			if zone != nil && !restricted[*zone] {
+				zones = append(zones, *zone)
+			}
+		}
+	}
+
+	sort.Strings(zones)
+	return zones
+}
+
+// isSKURestricted reports whether the SKU cannot be deployed in the given location
+// by the current subscription.
+func isSKURestricted(sku *armcompute.ResourceSKU, location string) bool {
+	for _, restriction := range sku.Restrictions {
+		if restriction.Type == nil || *restriction.Type != armcompute.ResourceSKURestrictionsTypeLocation {
+			continue
+		}
+
+		for _, value := range restriction.Values {
+			if value != nil && strings.EqualFold(*value, location) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
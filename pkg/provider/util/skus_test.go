@@ -0,0 +1,65 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
+)
+
+func TestGetSKUZones(t *testing.T) {
+	sku := &armcompute.ResourceSKU{
+		LocationInfo: []*armcompute.ResourceSKULocationInfo{
+			{Location: to.Ptr("westus"), Zones: []*string{to.Ptr("1")}},
+			{Location: to.Ptr("EastUS"), Zones: []*string{to.Ptr("3"), to.Ptr("1"), to.Ptr("2")}},
+		},
+		Restrictions: []*armcompute.ResourceSKURestrictions{
+			{
+				Type:            to.Ptr(armcompute.ResourceSKURestrictionsTypeZone),
+				RestrictionInfo: &armcompute.ResourceSKURestrictionInfo{Zones: []*string{to.Ptr("2")}},
+			},
+		},
+	}
+
+	got := getSKUZones(sku, "eastus")
+	if want := []string{"1", "3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("getSKUZones() = %v, want %v", got, want)
+	}
+
+	if got := getSKUZones(sku, "centralus"); len(got) != 0 {
+		t.Errorf("getSKUZones() = %v, want no zones", got)
+	}
+}
+
+func TestSupportsAcceleratedNetworking(t *testing.T) {
+	tests := []struct {
+		name         string
+		capabilities map[string]string
+		want         bool
+	}{
+		{
+			name:         "Supported",
+			capabilities: map[string]string{"AcceleratedNetworkingEnabled": "True"},
+			want:         true,
+		},
+		{
+			name:         "Not supported",
+			capabilities: map[string]string{"AcceleratedNetworkingEnabled": "False"},
+			want:         false,
+		},
+		{
+			name:         "Unknown",
+			capabilities: nil,
+			want:         false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := supportsAcceleratedNetworking(newTestSKU("Standard_D2s_v5", tt.capabilities)); got != tt.want {
+				t.Errorf("supportsAcceleratedNetworking() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	sshKeyBits           = 3072
+	sshPrivateKeyName    = "id_rsa"
+	authorizedKeysPath   = "/home/daytona/.ssh/authorized_keys"
+	sshKeyCommentPattern = "daytona@%s"
+)
+
+// getSSHPublicKey returns the public key authorized for the daytona admin user of the
+// target. Unless a public key is set in the target options, a keypair is generated and
+// its private key is stored in keyDir, encrypted with the SSH key passphrase.
+func getSSHPublicKey(targetId, keyDir string, opts *types.TargetOptions, logWriter io.Writer) (string, error) {
+	if opts.SSHPublicKey != "" {
+		return strings.TrimSpace(opts.SSHPublicKey), nil
+	}
+
+	err := validateSSHKeyPassphrase(opts)
+	if err != nil {
+		return "", err
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, sshKeyBits)
+	if err != nil {
+		return "", err
+	}
+
+	publicKey, err := ssh.NewPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return "", err
+	}
+
+	comment := fmt.Sprintf(sshKeyCommentPattern, targetId)
+	authorizedKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey))) + " " + comment
+
+	block, err := ssh.MarshalPrivateKeyWithPassphrase(privateKey, comment, []byte(opts.SSHKeyPassphrase))
+	if err != nil {
+		return "", err
+	}
+
+	err = os.MkdirAll(keyDir, 0700)
+	if err != nil {
+		return "", err
+	}
+
+	privateKeyPath := filepath.Join(keyDir, sshPrivateKeyName)
+	err = os.WriteFile(privateKeyPath, pem.EncodeToMemory(block), 0600)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.WriteFile(privateKeyPath+".pub", []byte(authorizedKey+"\n"), 0644)
+	if err != nil {
+		return "", err
+	}
+
+	logWriter.Write([]byte(fmt.Sprintf("SSH private key of the daytona user stored in %s\n", privateKeyPath)))
+
+	return authorizedKey, nil
+}
+
+// validateSSHKeyPassphrase checks that generated private keys can be encrypted. A
+// passphrase is only required when no SSH public key is set.
+func validateSSHKeyPassphrase(opts *types.TargetOptions) error {
+	if opts.SSHPublicKey == "" && opts.SSHKeyPassphrase == "" {
+		return fmt.Errorf("ssh key passphrase not set in env/target options. Set SSH Key Passphrase or AZURE_SSH_KEY_PASSPHRASE to encrypt the generated SSH key, or set SSH Public Key")
+	}
+
+	return nil
+}
+
+// DeleteSSHKey deletes the stored SSH keypair of the target, if there is one.
+func DeleteSSHKey(keyDir string) error {
+	return os.RemoveAll(keyDir)
+}
@@ -0,0 +1,71 @@
+package util
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestGetSSHPublicKey(t *testing.T) {
+	t.Run("Provided public key", func(t *testing.T) {
+		keyDir := filepath.Join(t.TempDir(), "target-id")
+		opts := &types.TargetOptions{SSHPublicKey: "ssh-ed25519 AAAA admin@example.com\n"}
+
+		got, err := getSSHPublicKey("target-id", keyDir, opts, &bytes.Buffer{})
+		if err != nil {
+			t.Fatalf("getSSHPublicKey() error = %v", err)
+		}
+		if got != "ssh-ed25519 AAAA admin@example.com" {
+			t.Errorf("getSSHPublicKey() = %v", got)
+		}
+		if _, err := os.Stat(keyDir); !os.IsNotExist(err) {
+			t.Errorf("getSSHPublicKey() created %s", keyDir)
+		}
+	})
+
+	t.Run("Generated key without passphrase", func(t *testing.T) {
+		keyDir := filepath.Join(t.TempDir(), "target-id")
+
+		_, err := getSSHPublicKey("target-id", keyDir, &types.TargetOptions{}, &bytes.Buffer{})
+		if err == nil {
+			t.Fatalf("getSSHPublicKey() expected an error without a passphrase")
+		}
+		if _, err := os.Stat(keyDir); !os.IsNotExist(err) {
+			t.Errorf("getSSHPublicKey() created %s", keyDir)
+		}
+	})
+
+	t.Run("Generated key with passphrase", func(t *testing.T) {
+		keyDir := filepath.Join(t.TempDir(), "target-id")
+		opts := &types.TargetOptions{SSHKeyPassphrase: "passphrase"}
+
+		got, err := getSSHPublicKey("target-id", keyDir, opts, &bytes.Buffer{})
+		if err != nil {
+			t.Fatalf("getSSHPublicKey() error = %v", err)
+		}
+
+		privateKey, err := os.ReadFile(filepath.Join(keyDir, sshPrivateKeyName))
+		if err != nil {
+			t.Fatalf("failed to read private key: %v", err)
+		}
+
+		if _, err := ssh.ParsePrivateKey(privateKey); err == nil {
+			t.Errorf("private key is not encrypted")
+		}
+
+		signer, err := ssh.ParsePrivateKeyWithPassphrase(privateKey, []byte("passphrase"))
+		if err != nil {
+			t.Fatalf("failed to decrypt private key: %v", err)
+		}
+
+		publicKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey())))
+		if got != publicKey+" daytona@target-id" {
+			t.Errorf("getSSHPublicKey() = %v, want %v", got, publicKey)
+		}
+	})
+}
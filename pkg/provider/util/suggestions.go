@@ -0,0 +1,140 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+)
+
+const (
+	disksResourceType = "disks"
+)
+
+// FetchSuggestions builds the target config suggestions from the locations and resource
+// SKUs available to the subscription. Resource SKUs are only listed for the given region,
+// as the subscription-wide list is tens of megabytes, so the VM sizes and disk types are
+// those of that region. Image URNs are not listed by Azure per subscription, so the
+// built-in image suggestions are kept.
+func FetchSuggestions(ctx context.Context, region string, clients *ClientFactory) (*types.Suggestions, error) {
+	regions, err := getVirtualMachineLocations(ctx, clients)
+	if err != nil {
+		return nil, err
+	}
+
+	pager := clients.resourceSKUs.NewListPager(&armcompute.ResourceSKUsClientListOptions{
+		Filter: to.Ptr(fmt.Sprintf("location eq '%s'", region)),
+	})
+
+	vmSizes := map[string]bool{}
+	diskTypes := map[string]bool{}
+
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, sku := range page.Value {
+			if sku.ResourceType == nil || sku.Name == nil {
+				continue
+			}
+
+			if len(getUnrestrictedLocations(sku)) == 0 {
+				continue
+			}
+
+			switch *sku.ResourceType {
+			case virtualMachinesResourceType:
+				vmSizes[*sku.Name] = true
+			case disksResourceType:
+				if isOSDiskType(*sku.Name) {
+					diskTypes[*sku.Name] = true
+				}
+			}
+		}
+	}
+
+	suggestions := types.GetStaticSuggestions()
+	if len(regions) > 0 {
+		suggestions.Regions = regions
+	}
+	if len(vmSizes) > 0 {
+		suggestions.VMSizes = sortedKeys(vmSizes)
+	}
+	if len(diskTypes) > 0 {
+		suggestions.DiskTypes = sortedKeys(diskTypes)
+	}
+
+	return suggestions, nil
+}
+
+// getVirtualMachineLocations returns the sorted names of the locations in which the
+// subscription can create virtual machines.
+func getVirtualMachineLocations(ctx context.Context, clients *ClientFactory) ([]string, error) {
+	resp, err := clients.providers.Get(ctx, "Microsoft.Compute", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := map[string]bool{}
+	for _, resourceType := range resp.ResourceTypes {
+		if resourceType.ResourceType == nil || *resourceType.ResourceType != virtualMachinesResourceType {
+			continue
+		}
+
+		for _, location := range resourceType.Locations {
+			if location != nil {
+				locations[getLocationName(*location)] = true
+			}
+		}
+	}
+
+	return sortedKeys(locations), nil
+}
+
+// getLocationName converts a location display name, e.g. "West US 2", to its name.
+func getLocationName(displayName string) string {
+	return strings.ToLower(strings.ReplaceAll(displayName, " ", ""))
+}
+
+// getUnrestrictedLocations returns the lowercase locations in which the SKU can be
+// deployed by the current subscription.
+func getUnrestrictedLocations(sku *armcompute.ResourceSKU) []string {
+	var locations []string
+	for _, location := range sku.Locations {
+		if location != nil && !isSKURestricted(sku, *location) {
+			locations = append(locations, strings.ToLower(*location))
+		}
+	}
+
+	return locations
+}
+
+// isOSDiskType reports whether the disk type can be used for an OS disk.
+func isOSDiskType(diskType string) bool {
+	switch armcompute.StorageAccountTypes(diskType) {
+	case armcompute.StorageAccountTypesStandardLRS,
+		armcompute.StorageAccountTypesStandardSSDLRS,
+		armcompute.StorageAccountTypesStandardSSDZRS,
+		armcompute.StorageAccountTypesPremiumLRS,
+		armcompute.StorageAccountTypesPremiumZRS:
+		return true
+	}
+
+	return false
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -5,19 +5,51 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
+
+	logwriters "github.com/daytonaio/daytona-provider-azure/internal/log"
 	"github.com/daytonaio/daytona-provider-azure/pkg/types"
 	"github.com/daytonaio/daytona/pkg/models"
 )
 
-func CreateTarget(target *models.Target, opts *types.TargetOptions, initScript string, logWriter io.Writer) error {
-	cred, err := getClientCredentials(opts)
+// CreateTarget creates the Azure resources of the target. Generated SSH keys are stored
+// in sshKeyDir.
+func CreateTarget(target *models.Target, opts *types.TargetOptions, clients *ClientFactory, initScript, sshKeyDir string, logWriter io.Writer) error {
+	spinner := logwriters.ShowSpinner(logWriter, "Validating target options", "Target options validated")
+	err := validateTargetOptions(opts, clients)
+	close(spinner)
+	if err != nil {
+		return fmt.Errorf("invalid target options: %w", err)
+	}
+
+	warning, err := getOutboundWarning(opts, clients)
 	if err != nil {
 		return err
 	}
+	if warning != "" {
+		logWriter.Write([]byte("WARNING: " + warning + "\n"))
+	}
+
+	err = validateEgressAllowList(target, opts, clients)
+	if err != nil {
+		return fmt.Errorf("invalid egress allow list: %w", err)
+	}
+
+	if opts.AcceleratedNetworking {
+		unsupportedSize, err := getAcceleratedNetworkingUnsupportedSize(opts, clients)
+		if err != nil {
+			return err
+		}
+		if unsupportedSize != "" {
+			logWriter.Write([]byte(fmt.Sprintf("WARNING: VM size %s does not support accelerated networking, turning it off\n", unsupportedSize)))
+			opts.AcceleratedNetworking = false
+		}
+	}
 
-	resourceGroupName, err := initResourceGroup(opts)
+	resourceGroupName, err := initResourceGroup(opts, clients)
 	if err != nil {
 		return err
 	}
@@ -25,8 +57,13 @@ func CreateTarget(target *models.Target, opts *types.TargetOptions, initScript s
 	envVars := target.EnvVars
 	envVars["DAYTONA_AGENT_LOG_FILE_PATH"] = "/home/daytona/.daytona-agent.log"
 
+	proxyScript, err := getProxyScript(opts)
+	if err != nil {
+		return err
+	}
+
 	customData := `#!/bin/bash
-useradd -m -d /home/daytona daytona
+` + proxyScript + `useradd -m -d /home/daytona daytona
 
 curl -fsSL https://get.docker.com | bash
 
@@ -39,11 +76,11 @@ EOF
 
 # Create a systemd drop-in file to modify the Docker service
 mkdir -p /etc/systemd/system/docker.service.d
-cat > /etc/systemd/system/docker.service.d/override.conf <<EOF
+cat > /etc/systemd/system/docker.service.d/override.conf <<'EOF'
 [Service]
 ExecStart=
 ExecStart=/usr/bin/dockerd
-EOF
+` + getSystemdEnvironment(getProxyEnvVars(opts)) + `EOF
 
 systemctl daemon-reload
 systemctl restart docker
@@ -66,7 +103,8 @@ echo "daytona ALL=(ALL) NOPASSWD:ALL" > /etc/sudoers.d/91-daytona
 	}
 	customData += initScript
 	customData += `
-echo '[Unit]
+cat > /etc/systemd/system/daytona-agent.service <<'EOF'
+[Unit]
 Description=Daytona Agent Service
 After=network.target
 
@@ -76,60 +114,101 @@ ExecStart=/usr/local/bin/daytona agent --target
 Restart=always
 `
 
-	for k, v := range envVars {
-		customData += fmt.Sprintf("Environment='%s=%s'\n", k, v)
-	}
+	customData += getSystemdEnvironment(envVars)
+	customData += getSystemdEnvironment(getProxyEnvVars(opts))
 
 	customData += `
 [Install]
-WantedBy=multi-user.target' > /etc/systemd/system/daytona-agent.service
+WantedBy=multi-user.target
+EOF
 systemctl daemon-reload
 systemctl enable daytona-agent.service
 systemctl start daytona-agent.service
 `
 
 	customDataEncoded := base64.StdEncoding.EncodeToString([]byte(customData))
-	return createVirtualMachine(target.Id, resourceGroupName, customDataEncoded, opts, cred, logWriter)
-}
 
-func StartTarget(target *models.Target, opts *types.TargetOptions) error {
-	cred, err := getClientCredentials(opts)
+	sshPublicKey, err := getSSHPublicKey(target.Id, sshKeyDir, opts, logWriter)
 	if err != nil {
-		return err
+		return fmt.Errorf("cannot create SSH key: %w", err)
 	}
 
-	computeClient, err := armcompute.NewVirtualMachinesClient(opts.SubscriptionId, cred, nil)
+	err = createVirtualMachine(target.Id, resourceGroupName, customDataEncoded, sshPublicKey, opts, clients, logWriter)
 	if err != nil {
 		return err
 	}
 
+	if opts.PrivateDNSZoneId != "" {
+		spinner = logwriters.ShowSpinner(logWriter, "Registering target in Azure Private DNS zone", "Target registered in Azure Private DNS zone")
+		err = registerPrivateDNSRecord(target, opts, clients)
+		close(spinner)
+		if err != nil {
+			return fmt.Errorf("cannot register private DNS record: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+// StartTarget starts the virtual machine of the target. Spot virtual machines can be
+// evicted at any time, so an eviction is reported when the virtual machine was deleted or
+// there is no Spot capacity to start it again.
+func StartTarget(target *models.Target, opts *types.TargetOptions, clients *ClientFactory, logWriter io.Writer) error {
+	computeClient := clients.virtualMachines
+
 	vmName := getResourceName(target.Id)
 	resourceGroup := getResourceGroupName(opts)
 
-	pollerResp, err := computeClient.BeginStart(context.Background(), resourceGroup, vmName, nil)
+	vm, err := computeClient.Get(context.Background(), resourceGroup, vmName, &armcompute.VirtualMachinesClientGetOptions{
+		Expand: to.Ptr(armcompute.InstanceViewTypesInstanceView),
+	})
 	if err != nil {
+		if isNotFoundError(err) && opts.IsSpot() && opts.GetEvictionPolicy() == types.EvictionPolicyDelete {
+			return fmt.Errorf("spot virtual machine %s was evicted and deleted, delete the target and create it again", vmName)
+		}
 		return err
 	}
 
-	_, err = pollerResp.PollUntilDone(context.Background(), nil)
+	powerState := getPowerState(&vm.VirtualMachine)
+	if powerState == "running" {
+		return nil
+	}
+
+	logWriter.Write([]byte(fmt.Sprintf("Starting virtual machine %s\n", vmName)))
+
+	pollerResp, err := computeClient.BeginStart(context.Background(), resourceGroup, vmName, nil)
+	if err == nil {
+		_, err = pollerResp.PollUntilDone(context.Background(), nil)
+	}
 	if err != nil {
+		if opts.IsSpot() && isAllocationError(err) {
+			return fmt.Errorf("there is no Spot capacity to start virtual machine %s, which may have been evicted, try again later: %w", vmName, err)
+		}
 		return err
 	}
 
 	return nil
 }
 
-func StopTarget(target *models.Target, opts *types.TargetOptions) error {
-	cred, err := getClientCredentials(opts)
-	if err != nil {
-		return err
+// getPowerState returns the power state of the virtual machine from its instance view,
+// e.g. running or deallocated.
+func getPowerState(vm *armcompute.VirtualMachine) string {
+	if vm.Properties == nil || vm.Properties.InstanceView == nil {
+		return ""
 	}
 
-	computeClient, err := armcompute.NewVirtualMachinesClient(opts.SubscriptionId, cred, nil)
-	if err != nil {
-		return err
+	for _, status := range vm.Properties.InstanceView.Statuses {
+		if status.Code != nil && strings.HasPrefix(*status.Code, "PowerState/") {
+			return strings.TrimPrefix(*status.Code, "PowerState/")
+		}
 	}
 
+	return ""
+}
+
+func StopTarget(target *models.Target, opts *types.TargetOptions, clients *ClientFactory) error {
+	computeClient := clients.virtualMachines
+
 	vmName := getResourceName(target.Id)
 	resourceGroupName := getResourceGroupName(opts)
 
@@ -146,40 +225,70 @@ func StopTarget(target *models.Target, opts *types.TargetOptions) error {
 	return nil
 }
 
-func DeleteTarget(target *models.Target, opts *types.TargetOptions) error {
-	cred, err := getClientCredentials(opts)
-	if err != nil {
-		return err
+func DeleteTarget(target *models.Target, opts *types.TargetOptions, clients *ClientFactory) error {
+	if opts.PrivateDNSZoneId != "" {
+		err := deletePrivateDNSRecord(target, opts, clients)
+		if err != nil {
+			return fmt.Errorf("cannot delete private DNS record: %+v", err)
+		}
 	}
 
-	err = deleteVirtualMachine(target.Id, opts, cred)
+	err := deleteVirtualMachine(target.Id, opts, clients)
 	if err != nil {
 		return fmt.Errorf("cannot delete virtual machine: %+v", err)
 	}
 
-	err = deleteDisk(target.Id, opts, cred)
+	err = deleteDisk(target.Id, opts, clients)
 	if err != nil {
 		return fmt.Errorf("cannot delete instance disk: %+v", err)
 	}
 
-	err = deleteNetworkInterface(target.Id, opts, cred)
+	err = deleteNetworkInterface(target.Id, opts, clients)
 	if err != nil {
 		return fmt.Errorf("cannot delete network interface: %+v", err)
 	}
 
-	vNetName := getResourceName(fmt.Sprintf("vnet-%s", target.Id))
+	if opts.HasPublicIP() {
+		err = deletePublicIPAddress(target.Id, opts, clients)
+		if err != nil {
+			return fmt.Errorf("cannot delete public IP address: %+v", err)
+		}
+	}
+
+	err = deleteNetworkSecurityGroup(target.Id, opts, clients)
+	if err != nil {
+		return fmt.Errorf("cannot delete network security group: %+v", err)
+	}
+
+	// The existing subnet and its virtual network are not owned by Daytona
+	if opts.SubnetId != "" {
+		return nil
+	}
+
+	if opts.NetworkMode == types.NetworkModeShared {
+		return deleteSharedSubnet(target.Id, opts, clients)
+	}
+
+	vNetName := getVirtualNetworkName(target.Id, opts)
 	subnetName := getResourceName(fmt.Sprintf("subnet-%s", target.Id))
 
-	err = deleteSubnet(vNetName, subnetName, opts, cred)
+	err = deleteSubnet(vNetName, subnetName, opts, clients)
 	if err != nil {
 		return fmt.Errorf("cannot delete subnet: %+v", err)
 	}
 
-	err = deleteVirtualNetwork(vNetName, opts, cred)
+	err = deleteVirtualNetwork(vNetName, opts, clients)
 	if err != nil {
 		return fmt.Errorf("cannot delete virtual network: %+v", err)
 	}
 
+	if opts.NATGateway == types.NATGatewayCreate {
+		err = deleteNATGateway(getNATGatewayName(target.Id, opts), opts, clients)
+		if err != nil {
+			return fmt.Errorf("cannot delete NAT gateway: %+v", err)
+		}
+	}
+
 	return nil
 }
 
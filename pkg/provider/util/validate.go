@@ -0,0 +1,360 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+)
+
+// validateTargetOptions checks the target options against the capabilities of the
+// subscription and region, so that invalid options are rejected before any Azure
+// resource is created.
+func validateTargetOptions(opts *types.TargetOptions, clients *ClientFactory) error {
+	err := validateSSHKeyPassphrase(opts)
+	if err != nil {
+		return err
+	}
+
+	skus, err := listVirtualMachineSKUs(opts.Region, clients)
+	if err != nil {
+		return fmt.Errorf("failed to list VM sizes in %s: %w", opts.Region, err)
+	}
+
+	if len(skus) == 0 {
+		return fmt.Errorf("region %s does not exist or offers no virtual machine sizes", opts.Region)
+	}
+
+	image, imageDiskSize, err := getImage(opts.ImageURN, opts.Region, clients)
+	if err != nil {
+		return err
+	}
+
+	if len(opts.VMSizeList()) == 0 {
+		return fmt.Errorf("no VM size set")
+	}
+
+	for _, vmSize := range opts.VMSizeList() {
+		err = validateVMSizeOptions(vmSize, image, opts, skus)
+		if err != nil {
+			return err
+		}
+	}
+
+	if imageDiskSize > 0 && opts.DiskSize < imageDiskSize {
+		return fmt.Errorf("disk size %d GB is smaller than the %d GB OS disk of image %s", opts.DiskSize, imageDiskSize, opts.ImageURN)
+	}
+
+	if opts.SubnetId != "" {
+		err = validateSubnet(opts, clients)
+	} else {
+		err = validateAddressSpace(opts, clients)
+	}
+	if err != nil {
+		return err
+	}
+
+	if opts.NATGatewayId != "" {
+		err = validateNATGateway(opts, clients)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateNATGateway checks that the existing NAT gateway exists and is in the target
+// subscription and region, as it can only be attached to subnets there.
+func validateNATGateway(opts *types.TargetOptions, clients *ClientFactory) error {
+	natGatewayId, err := arm.ParseResourceID(opts.NATGatewayId)
+	if err != nil {
+		return fmt.Errorf("invalid NAT gateway id %s: %w", opts.NATGatewayId, err)
+	}
+
+	if !strings.EqualFold(natGatewayId.SubscriptionID, opts.SubscriptionId) {
+		return fmt.Errorf("NAT gateway %s is not in subscription %s", opts.NATGatewayId, opts.SubscriptionId)
+	}
+
+	natGatewaysClient, err := clients.getNatGatewaysClient(natGatewayId.SubscriptionID)
+	if err != nil {
+		return err
+	}
+
+	resp, err := natGatewaysClient.Get(context.Background(), natGatewayId.ResourceGroupName, natGatewayId.Name, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get NAT gateway %s: %w", natGatewayId.Name, err)
+	}
+
+	if resp.Location == nil || !strings.EqualFold(*resp.Location, opts.Region) {
+		return fmt.Errorf("NAT gateway %s is not in region %s", natGatewayId.Name, opts.Region)
+	}
+
+	return nil
+}
+
+// validateVMSizeOptions checks the options that depend on the VM size. Every size of
+// the VM size list is checked, as any of them can end up being used.
+func validateVMSizeOptions(vmSize string, image *armcompute.VirtualMachineImage, opts *types.TargetOptions, skus []*armcompute.ResourceSKU) error {
+	sku, err := validateVMSize(vmSize, opts.Region, skus)
+	if err != nil {
+		return err
+	}
+
+	err = validateDiskType(opts.DiskType, vmSize, sku)
+	if err != nil {
+		return err
+	}
+
+	if opts.AvailabilityZone != "" && opts.AvailabilityZone != types.AvailabilityZoneAny {
+		zones := getSKUZones(sku, opts.Region)
+		if !slices.Contains(zones, opts.AvailabilityZone) {
+			return fmt.Errorf("VM size %s is not offered in availability zone %s of %s, available zones: [%s]",
+				vmSize, opts.AvailabilityZone, opts.Region, strings.Join(zones, ", "))
+		}
+	}
+
+	if opts.IsSpot() && !supportsSpot(sku) {
+		return fmt.Errorf("VM size %s does not support Spot priority", vmSize)
+	}
+
+	return validateSecurityType(opts.GetSecurityType(), opts.ImageURN, image, vmSize, sku)
+}
+
+// validateVMSize checks that the VM size is offered in the region and not restricted
+// for the subscription, and returns its SKU.
+func validateVMSize(vmSize, region string, skus []*armcompute.ResourceSKU) (*armcompute.ResourceSKU, error) {
+	sku := findSKU(skus, vmSize)
+	if sku == nil {
+		return nil, fmt.Errorf("VM size %s is not offered in %s%s", vmSize, region, getNearestVMSizesHint(vmSize, region, skus))
+	}
+
+	if isSKURestricted(sku, region) {
+		return nil, fmt.Errorf("VM size %s is not available for this subscription in %s%s", vmSize, region, getNearestVMSizesHint(vmSize, region, skus))
+	}
+
+	return sku, nil
+}
+
+// getNearestVMSizesHint returns an error message suffix naming the available VM sizes
+// in the region closest to the requested one.
+func getNearestVMSizesHint(vmSize, region string, skus []*armcompute.ResourceSKU) string {
+	target, _ := types.GetVMSizeInfo(vmSize)
+	if sku := findSKU(skus, vmSize); sku != nil {
+		target = getVMSizeInfo(sku)
+	}
+
+	candidates := []types.VMSizeInfo{}
+	for _, sku := range skus {
+		if sku.Name != nil && !isSKURestricted(sku, region) {
+			candidates = append(candidates, getVMSizeInfo(sku))
+		}
+	}
+
+	nearest := types.NearestVMSizes(target, candidates, 3)
+	if len(nearest) == 0 {
+		return ""
+	}
+
+	hints := []string{}
+	for _, name := range nearest {
+		for _, candidate := range candidates {
+			if candidate.Name == name {
+				hints = append(hints, types.FormatVMSizeInfo(candidate))
+			}
+		}
+	}
+
+	return ". Nearest available sizes: " + strings.Join(hints, "; ")
+}
+
+// getAcceleratedNetworkingUnsupportedSize returns the first VM size of the VM size list
+// that does not support accelerated networking in the target region. The network
+// interface is shared by all sizes, so all of them have to support it.
+func getAcceleratedNetworkingUnsupportedSize(opts *types.TargetOptions, clients *ClientFactory) (string, error) {
+	skus, err := listVirtualMachineSKUs(opts.Region, clients)
+	if err != nil {
+		return "", fmt.Errorf("failed to list VM sizes in %s: %w", opts.Region, err)
+	}
+
+	for _, vmSize := range opts.VMSizeList() {
+		sku := findSKU(skus, vmSize)
+		if sku == nil || !supportsAcceleratedNetworking(sku) {
+			return vmSize, nil
+		}
+	}
+
+	return "", nil
+}
+
+// validateSubnet checks that the existing subnet exists and that its virtual network is
+// in the target region.
+func validateSubnet(opts *types.TargetOptions, clients *ClientFactory) error {
+	vNet, subnet, err := getExistingSubnet(opts.SubnetId, clients)
+	if err != nil {
+		return err
+	}
+
+	if vNet.Location == nil || !strings.EqualFold(*vNet.Location, opts.Region) {
+		return fmt.Errorf("virtual network %s is not in region %s", *vNet.Name, opts.Region)
+	}
+
+	if subnet == nil {
+		return fmt.Errorf("subnet %s does not exist in virtual network %s", opts.SubnetId, *vNet.Name)
+	}
+
+	return nil
+}
+
+// validateSecurityType checks that the image and the VM size support the security type.
+// TrustedLaunch and ConfidentialVM both require generation 2.
+func validateSecurityType(securityType, imageURN string, image *armcompute.VirtualMachineImage, vmSize string, sku *armcompute.ResourceSKU) error {
+	if securityType == types.SecurityTypeStandard {
+		return nil
+	}
+
+	if image.Properties == nil || image.Properties.HyperVGeneration == nil || *image.Properties.HyperVGeneration != armcompute.HyperVGenerationTypesV2 {
+		return fmt.Errorf("image %s is not a generation 2 image, which the %s security type requires", imageURN, securityType)
+	}
+
+	if generations, ok := getSKUCapability(sku, "HyperVGenerations"); ok && !strings.Contains(generations, "V2") {
+		return fmt.Errorf("VM size %s does not support generation 2 images, which the %s security type requires", vmSize, securityType)
+	}
+
+	imageSecurityType, hasImageSecurityType := getImageFeature(image, "SecurityType")
+
+	switch securityType {
+	case types.SecurityTypeTrustedLaunch:
+		if hasImageSecurityType && !strings.Contains(strings.ToLower(imageSecurityType), "trustedlaunch") {
+			return fmt.Errorf("image %s does not support the %s security type", imageURN, securityType)
+		}
+
+		if disabled, ok := getSKUCapability(sku, "TrustedLaunchDisabled"); ok && strings.EqualFold(disabled, "True") {
+			return fmt.Errorf("VM size %s does not support the %s security type", vmSize, securityType)
+		}
+	case types.SecurityTypeConfidentialVM:
+		if !strings.Contains(strings.ToLower(imageSecurityType), "confidentialvm") {
+			return fmt.Errorf("image %s does not support the %s security type, use a confidential VM image such as "+
+				"Canonical:0001-com-ubuntu-confidential-vm-jammy:22_04-lts-cvm:latest", imageURN, securityType)
+		}
+
+		if _, ok := getSKUCapability(sku, "ConfidentialComputingType"); !ok {
+			return fmt.Errorf("VM size %s does not support the %s security type, use a confidential computing size such as Standard_DC2as_v5", vmSize, securityType)
+		}
+	}
+
+	return nil
+}
+
+// getImageFeature returns the value of the image feature with the given name.
+func getImageFeature(image *armcompute.VirtualMachineImage, name string) (string, bool) {
+	if image.Properties == nil {
+		return "", false
+	}
+
+	for _, feature := range image.Properties.Features {
+		if feature.Name != nil && feature.Value != nil && strings.EqualFold(*feature.Name, name) {
+			return *feature.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// validateDiskType checks that the disk type can be used as an OS disk and is
+// supported by the VM size.
+func validateDiskType(diskType, vmSize string, sku *armcompute.ResourceSKU) error {
+	var storageAccountType *armcompute.StorageAccountTypes
+	for _, value := range armcompute.PossibleStorageAccountTypesValues() {
+		if strings.EqualFold(string(value), diskType) {
+			storageAccountType = to.Ptr(value)
+		}
+	}
+
+	if storageAccountType == nil {
+		return fmt.Errorf("unsupported disk type %s", diskType)
+	}
+
+	switch *storageAccountType {
+	case armcompute.StorageAccountTypesUltraSSDLRS, armcompute.StorageAccountTypesPremiumV2LRS:
+		return fmt.Errorf("disk type %s cannot be used as an OS disk", diskType)
+	case armcompute.StorageAccountTypesPremiumLRS, armcompute.StorageAccountTypesPremiumZRS:
+		premiumIO, _ := getSKUCapability(sku, "PremiumIO")
+		if !strings.EqualFold(premiumIO, "True") {
+			return fmt.Errorf("disk type %s is not supported by VM size %s, choose a size with premium storage support (e.g. an \"s\" size)", diskType, vmSize)
+		}
+	}
+
+	return nil
+}
+
+// getImage resolves the image URN in the region and returns the image together
+// with the size of its OS disk in GB, or 0 if Azure does not report it.
+func getImage(imageURN, region string, clients *ClientFactory) (*armcompute.VirtualMachineImage, int, error) {
+	publisher, offer, sku, version, err := extractURNParts(imageURN)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid image URN %s: %w", imageURN, err)
+	}
+
+	if strings.EqualFold(version, "latest") {
+		resp, err := clients.virtualMachineImages.List(context.Background(), region, publisher, offer, sku, &armcompute.VirtualMachineImagesClientListOptions{
+			Top:     to.Ptr[int32](1),
+			Orderby: to.Ptr("name desc"),
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("image %s not found in %s: %w", imageURN, region, err)
+		}
+
+		if len(resp.VirtualMachineImageResourceArray) == 0 || resp.VirtualMachineImageResourceArray[0].Name == nil {
+			return nil, 0, fmt.Errorf("image %s not found in %s", imageURN, region)
+		}
+
+		version = *resp.VirtualMachineImageResourceArray[0].Name
+	}
+
+	// The SDK model does not include the OS disk size, so it is read from the raw response.
+	var rawResp *http.Response
+	ctx := runtime.WithCaptureResponse(context.Background(), &rawResp)
+
+	resp, err := clients.virtualMachineImages.Get(ctx, region, publisher, offer, sku, version, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("image %s not found in %s: %w", imageURN, region, err)
+	}
+
+	return &resp.VirtualMachineImage, getImageDiskSize(rawResp), nil
+}
+
+// getImageDiskSize reads properties.osDiskImage.sizeInGb from a raw image response.
+func getImageDiskSize(rawResp *http.Response) int {
+	if rawResp == nil {
+		return 0
+	}
+
+	payload, err := runtime.Payload(rawResp)
+	if err != nil {
+		return 0
+	}
+
+	var image struct {
+		Properties struct {
+			OSDiskImage struct {
+				SizeInGb int `json:"sizeInGb"`
+			} `json:"osDiskImage"`
+		} `json:"properties"`
+	}
+
+	err = json.Unmarshal(payload, &image)
+	if err != nil {
+		return 0
+	}
+
+	return image.Properties.OSDiskImage.SizeInGb
+}
@@ -0,0 +1,228 @@
+package util
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
+	"github.com/daytonaio/daytona-provider-azure/pkg/types"
+)
+
+func TestValidateSecurityType(t *testing.T) {
+	newImage := func(generation armcompute.HyperVGenerationTypes, securityType string) *armcompute.VirtualMachineImage {
+		image := &armcompute.VirtualMachineImage{
+			Properties: &armcompute.VirtualMachineImageProperties{
+				HyperVGeneration: to.Ptr(generation),
+			},
+		}
+		if securityType != "" {
+			image.Properties.Features = []*armcompute.VirtualMachineImageFeature{
+				{Name: to.Ptr("SecurityType"), Value: to.Ptr(securityType)},
+			}
+		}
+		return image
+	}
+
+	newSKU := func(capabilities map[string]string) *armcompute.ResourceSKU {
+		sku := &armcompute.ResourceSKU{Name: to.Ptr("Standard_D2s_v5")}
+		for name, value := range capabilities {
+			sku.Capabilities = append(sku.Capabilities, &armcompute.ResourceSKUCapabilities{Name: to.Ptr(name), Value: to.Ptr(value)})
+		}
+		return sku
+	}
+
+	tests := []struct {
+		name         string
+		securityType string
+		image        *armcompute.VirtualMachineImage
+		sku          *armcompute.ResourceSKU
+		wantErr      bool
+	}{
+		{
+			name:         "Standard on generation 1",
+			securityType: types.SecurityTypeStandard,
+			image:        newImage(armcompute.HyperVGenerationTypesV1, ""),
+			sku:          newSKU(nil),
+		},
+		{
+			name:         "Trusted launch",
+			securityType: types.SecurityTypeTrustedLaunch,
+			image:        newImage(armcompute.HyperVGenerationTypesV2, "TrustedLaunchSupported"),
+			sku:          newSKU(map[string]string{"HyperVGenerations": "V1,V2"}),
+		},
+		{
+			name:         "Trusted launch on generation 1",
+			securityType: types.SecurityTypeTrustedLaunch,
+			image:        newImage(armcompute.HyperVGenerationTypesV1, ""),
+			sku:          newSKU(nil),
+			wantErr:      true,
+		},
+		{
+			name:         "Trusted launch disabled for VM size",
+			securityType: types.SecurityTypeTrustedLaunch,
+			image:        newImage(armcompute.HyperVGenerationTypesV2, ""),
+			sku:          newSKU(map[string]string{"HyperVGenerations": "V2", "TrustedLaunchDisabled": "True"}),
+			wantErr:      true,
+		},
+		{
+			name:         "Confidential VM",
+			securityType: types.SecurityTypeConfidentialVM,
+			image:        newImage(armcompute.HyperVGenerationTypesV2, "ConfidentialVmSupported"),
+			sku:          newSKU(map[string]string{"HyperVGenerations": "V2", "ConfidentialComputingType": "SNP"}),
+		},
+		{
+			name:         "Confidential VM on trusted launch image",
+			securityType: types.SecurityTypeConfidentialVM,
+			image:        newImage(armcompute.HyperVGenerationTypesV2, "TrustedLaunchSupported"),
+			sku:          newSKU(map[string]string{"HyperVGenerations": "V2", "ConfidentialComputingType": "SNP"}),
+			wantErr:      true,
+		},
+		{
+			name:         "Confidential VM on regular VM size",
+			securityType: types.SecurityTypeConfidentialVM,
+			image:        newImage(armcompute.HyperVGenerationTypesV2, "TrustedLaunchAndConfidentialVmSupported"),
+			sku:          newSKU(map[string]string{"HyperVGenerations": "V2"}),
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSecurityType(tt.securityType, "publisher:offer:sku:latest", tt.image, *tt.sku.Name, tt.sku)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateSecurityType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func newTestSKU(name string, capabilities map[string]string) *armcompute.ResourceSKU {
+	sku := &armcompute.ResourceSKU{Name: to.Ptr(name)}
+	for capability, value := range capabilities {
+		sku.Capabilities = append(sku.Capabilities, &armcompute.ResourceSKUCapabilities{Name: to.Ptr(capability), Value: to.Ptr(value)})
+	}
+	return sku
+}
+
+func TestValidateDiskType(t *testing.T) {
+	premiumSKU := newTestSKU("Standard_D2s_v5", map[string]string{"PremiumIO": "True"})
+	standardSKU := newTestSKU("Standard_D2_v5", map[string]string{"PremiumIO": "False"})
+
+	tests := []struct {
+		name     string
+		diskType string
+		sku      *armcompute.ResourceSKU
+		wantErr  bool
+	}{
+		{
+			name:     "Premium SSD on premium storage size",
+			diskType: "Premium_LRS",
+			sku:      premiumSKU,
+		},
+		{
+			name:     "Premium SSD on standard size",
+			diskType: "Premium_LRS",
+			sku:      standardSKU,
+			wantErr:  true,
+		},
+		{
+			name:     "Standard SSD in lower case",
+			diskType: "standardssd_lrs",
+			sku:      standardSKU,
+		},
+		{
+			name:     "Ultra disk as OS disk",
+			diskType: "UltraSSD_LRS",
+			sku:      premiumSKU,
+			wantErr:  true,
+		},
+		{
+			name:     "Unknown disk type",
+			diskType: "Fast_LRS",
+			sku:      premiumSKU,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDiskType(tt.diskType, *tt.sku.Name, tt.sku)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateDiskType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetImageDiskSize(t *testing.T) {
+	newResponse := func(body string) *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}
+	}
+
+	tests := []struct {
+		name    string
+		rawResp *http.Response
+		want    int
+	}{
+		{
+			name:    "OS disk size",
+			rawResp: newResponse(`{"properties":{"osDiskImage":{"operatingSystem":"Linux","sizeInGb":30}}}`),
+			want:    30,
+		},
+		{
+			name:    "No OS disk size",
+			rawResp: newResponse(`{"properties":{}}`),
+			want:    0,
+		},
+		{
+			name:    "Invalid response",
+			rawResp: newResponse(`not json`),
+			want:    0,
+		},
+		{
+			name:    "No response",
+			rawResp: nil,
+			want:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getImageDiskSize(tt.rawResp); got != tt.want {
+				t.Errorf("getImageDiskSize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetNearestVMSizesHint(t *testing.T) {
+	restricted := newTestSKU("Standard_D4as_v5", map[string]string{"vCPUs": "4", "MemoryGB": "16"})
+	restricted.Restrictions = []*armcompute.ResourceSKURestrictions{
+		{Type: to.Ptr(armcompute.ResourceSKURestrictionsTypeLocation), Values: []*string{to.Ptr("eastus")}},
+	}
+
+	skus := []*armcompute.ResourceSKU{
+		newTestSKU("Standard_D2s_v5", map[string]string{"vCPUs": "2", "MemoryGB": "8"}),
+		newTestSKU("Standard_D4s_v5", map[string]string{"vCPUs": "4", "MemoryGB": "16"}),
+		newTestSKU("Standard_D8s_v5", map[string]string{"vCPUs": "8", "MemoryGB": "32"}),
+		newTestSKU("Standard_D64s_v5", map[string]string{"vCPUs": "64", "MemoryGB": "256"}),
+		restricted,
+	}
+
+	hint := getNearestVMSizesHint("Standard_D4s_v4", "eastus", skus)
+	if !strings.HasPrefix(hint, ". Nearest available sizes: Standard_D4s_v5") {
+		t.Errorf("getNearestVMSizesHint() = %v, want Standard_D4s_v5 first", hint)
+	}
+	for _, vmSize := range []string{"Standard_D4as_v5", "Standard_D64s_v5"} {
+		if strings.Contains(hint, vmSize) {
+			t.Errorf("getNearestVMSizesHint() = %v, should not contain %s", hint, vmSize)
+		}
+	}
+
+	if hint := getNearestVMSizesHint("Standard_D4s_v4", "eastus", nil); hint != "" {
+		t.Errorf("getNearestVMSizesHint() = %v, want no hint without available sizes", hint)
+	}
+}
@@ -9,7 +9,13 @@ type TargetMetadata struct {
 	VirtualMachineName     string
 	VirtualMachineSizeType string
 	Location               string
+	Zone                   string `json:",omitempty"`
 	Created                string
+	PublicIPAddress        string   `json:",omitempty"`
+	FQDN                   string   `json:",omitempty"`
+	EgressIPAddresses      []string `json:",omitempty"`
+	AcceleratedNetworking  bool
+	PrivateDNSName         string `json:",omitempty"`
 }
 
 // ToTargetMetadata converts and maps values from an armcompute.VirtualMachine to a TargetMetadata.
@@ -32,6 +38,10 @@ func ToTargetMetadata(vm *armcompute.VirtualMachine) TargetMetadata {
 		metadata.Location = *vm.Location
 	}
 
+	if len(vm.Zones) > 0 && vm.Zones[0] != nil {
+		metadata.Zone = *vm.Zones[0]
+	}
+
 	if vm.Properties != nil && vm.Properties.TimeCreated != nil {
 		metadata.Created = vm.Properties.TimeCreated.String()
 	}
@@ -0,0 +1,169 @@
+package types
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	RuleProtocolAny  = "*"
+	RuleProtocolTCP  = "Tcp"
+	RuleProtocolUDP  = "Udp"
+	RuleProtocolICMP = "Icmp"
+)
+
+const (
+	DefaultAddressSpace = "10.10.0.0/16"
+	DefaultSubnetPrefix = "10.10.10.0/24"
+	// maxSubnetPrefixLength is the smallest subnet supported by Azure.
+	maxSubnetPrefixLength = 29
+)
+
+// SecurityRule is a custom network security group rule that allows traffic from or to
+// an address.
+type SecurityRule struct {
+	Protocol  string
+	PortRange string
+	// Address is the source of inbound rules and the destination of outbound rules.
+	Address string
+}
+
+var serviceTagPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9.]*$`)
+
+// ParseSecurityRules parses a comma-separated list of <protocol>:<ports>:<address> rules,
+// e.g. "tcp:22:10.0.0.0/8,tcp:8000-8100:VirtualNetwork".
+func ParseSecurityRules(rules string) ([]SecurityRule, error) {
+	securityRules := []SecurityRule{}
+
+	for _, rule := range strings.Split(rules, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		parts := strings.Split(rule, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid rule %s: expected <protocol>:<ports>:<address>", rule)
+		}
+
+		protocol, err := parseRuleProtocol(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %s: %w", rule, err)
+		}
+
+		err = validatePortRange(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %s: %w", rule, err)
+		}
+
+		err = validateRuleAddress(parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %s: %w", rule, err)
+		}
+
+		securityRules = append(securityRules, SecurityRule{
+			Protocol:  protocol,
+			PortRange: parts[1],
+			Address:   parts[2],
+		})
+	}
+
+	return securityRules, nil
+}
+
+// parseRuleProtocol returns the network security group protocol name.
+func parseRuleProtocol(protocol string) (string, error) {
+	for _, value := range []string{RuleProtocolAny, RuleProtocolTCP, RuleProtocolUDP, RuleProtocolICMP} {
+		if strings.EqualFold(protocol, value) {
+			return value, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported protocol %s", protocol)
+}
+
+// validatePortRange checks that the port range is "*", a port or a range of ports.
+func validatePortRange(portRange string) error {
+	if portRange == "*" {
+		return nil
+	}
+
+	bounds := strings.SplitN(portRange, "-", 2)
+	ports := []int{}
+	for _, bound := range bounds {
+		port, err := strconv.Atoi(bound)
+		if err != nil || port < 1 || port > 65535 {
+			return fmt.Errorf("invalid port range %s", portRange)
+		}
+		ports = append(ports, port)
+	}
+
+	if len(ports) == 2 && ports[0] > ports[1] {
+		return fmt.Errorf("invalid port range %s", portRange)
+	}
+
+	return nil
+}
+
+// validateRuleAddress checks that the address is "*", an IP address, a CIDR block or a
+// service tag such as VirtualNetwork or Storage.EastUS.
+func validateRuleAddress(address string) error {
+	if address == "*" || serviceTagPattern.MatchString(address) {
+		return nil
+	}
+
+	if strings.Contains(address, "/") {
+		if _, err := netip.ParsePrefix(address); err == nil {
+			return nil
+		}
+	} else if _, err := netip.ParseAddr(address); err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("invalid address %s", address)
+}
+
+// validateAddressPrefixes checks that the address space and the subnet prefix are IPv4
+// CIDR blocks and that the subnet lies within the address space.
+func validateAddressPrefixes(addressSpace, subnetPrefix string) error {
+	space, err := parseCIDR(addressSpace)
+	if err != nil {
+		return fmt.Errorf("invalid address space: %w", err)
+	}
+
+	subnet, err := parseCIDR(subnetPrefix)
+	if err != nil {
+		return fmt.Errorf("invalid subnet prefix: %w", err)
+	}
+
+	if subnet.Bits() > maxSubnetPrefixLength {
+		return fmt.Errorf("subnet prefix %s is smaller than /%d", subnetPrefix, maxSubnetPrefixLength)
+	}
+
+	if subnet.Bits() < space.Bits() || !space.Contains(subnet.Addr()) {
+		return fmt.Errorf("subnet prefix %s is not within address space %s", subnetPrefix, addressSpace)
+	}
+
+	return nil
+}
+
+// parseCIDR parses an IPv4 CIDR block whose address is the first address of the block.
+func parseCIDR(cidr string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	if !prefix.Addr().Is4() {
+		return netip.Prefix{}, fmt.Errorf("%s is not an IPv4 CIDR block", cidr)
+	}
+
+	if prefix.Masked() != prefix {
+		return netip.Prefix{}, fmt.Errorf("%s is not a network address, did you mean %s", cidr, prefix.Masked())
+	}
+
+	return prefix, nil
+}
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSecurityRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   string
+		want    []SecurityRule
+		wantErr bool
+	}{
+		{
+			name:  "Empty rules",
+			rules: "",
+			want:  []SecurityRule{},
+		},
+		{
+			name:  "Multiple rules",
+			rules: "tcp:22:10.0.0.0/8, udp:60000-61000:VirtualNetwork,*:*:203.0.113.7",
+			want: []SecurityRule{
+				{Protocol: RuleProtocolTCP, PortRange: "22", Address: "10.0.0.0/8"},
+				{Protocol: RuleProtocolUDP, PortRange: "60000-61000", Address: "VirtualNetwork"},
+				{Protocol: RuleProtocolAny, PortRange: "*", Address: "203.0.113.7"},
+			},
+		},
+		{
+			name:    "Missing source",
+			rules:   "tcp:22",
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported protocol",
+			rules:   "sctp:22:*",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid port range",
+			rules:   "tcp:9000-8000:*",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid source",
+			rules:   "tcp:22:10.0.0.0/33",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSecurityRules(tt.rules)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseSecurityRules() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSecurityRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package types
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/daytonaio/daytona/pkg/models"
+	"github.com/daytonaio/daytona/pkg/provider"
+)
+
+// Preset is a named target config. Its options override the defaults of the target config manifest.
+type Preset struct {
+	Name    string                 `json:"name"`
+	Options map[string]interface{} `json:"options"`
+}
+
+var presets = []Preset{
+	{
+		Name: "azure-small",
+		Options: map[string]interface{}{
+			"VM Size":   "Standard_B2s",
+			"Disk Type": "StandardSSD_LRS",
+			"Disk Size": 30,
+		},
+	},
+	{
+		Name: "azure-medium",
+		Options: map[string]interface{}{
+			"VM Size":   "Standard_D4s_v5",
+			"Disk Type": "Premium_LRS",
+			"Disk Size": 64,
+		},
+	},
+	{
+		Name: "azure-large",
+		Options: map[string]interface{}{
+			"VM Size":   "Standard_D16s_v5",
+			"Disk Type": "Premium_LRS",
+			"Disk Size": 128,
+		},
+	},
+}
+
+// GetPresetTargetConfigs returns the built-in presets followed by the custom presets.
+// A custom preset replaces the built-in preset with the same name.
+func GetPresetTargetConfigs(customPresets []Preset) ([]provider.TargetConfig, error) {
+	defaults := getManifestDefaults(GetTargetConfigManifest())
+
+	var allPresets []Preset
+	for _, preset := range presets {
+		if !containsPreset(customPresets, preset.Name) {
+			allPresets = append(allPresets, preset)
+		}
+	}
+	allPresets = append(allPresets, customPresets...)
+
+	targetConfigs := []provider.TargetConfig{}
+	for _, preset := range allPresets {
+		options := map[string]interface{}{}
+		for name, value := range defaults {
+			options[name] = value
+		}
+		for name, value := range preset.Options {
+			options[name] = value
+		}
+
+		optionsJson, err := json.Marshal(options)
+		if err != nil {
+			return nil, err
+		}
+
+		targetConfigs = append(targetConfigs, provider.TargetConfig{
+			Name:    preset.Name,
+			Options: string(optionsJson),
+		})
+	}
+
+	return targetConfigs, nil
+}
+
+// getManifestDefaults returns the default values of the manifest properties converted to their property type.
+func getManifestDefaults(manifest *models.TargetConfigManifest) map[string]interface{} {
+	defaults := map[string]interface{}{}
+	for name, property := range *manifest {
+		if property.DefaultValue == "" {
+			continue
+		}
+
+		switch property.Type {
+		case models.TargetConfigPropertyTypeInt:
+			value, err := strconv.Atoi(property.DefaultValue)
+			if err == nil {
+				defaults[name] = value
+			}
+		case models.TargetConfigPropertyTypeFloat:
+			value, err := strconv.ParseFloat(property.DefaultValue, 64)
+			if err == nil {
+				defaults[name] = value
+			}
+		case models.TargetConfigPropertyTypeBoolean:
+			value, err := strconv.ParseBool(property.DefaultValue)
+			if err == nil {
+				defaults[name] = value
+			}
+		default:
+			defaults[name] = property.DefaultValue
+		}
+	}
+
+	return defaults
+}
+
+func containsPreset(presets []Preset, name string) bool {
+	for _, preset := range presets {
+		if preset.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGetPresetTargetConfigs(t *testing.T) {
+	customPresets := []Preset{
+		{
+			Name: "azure-small",
+			Options: map[string]interface{}{
+				"VM Size": "Standard_B2ms",
+			},
+		},
+		{
+			Name: "azure-gpu",
+			Options: map[string]interface{}{
+				"Region":    "eastus",
+				"VM Size":   "Standard_NC24ads_A100_v4",
+				"Disk Size": 256,
+			},
+		},
+	}
+
+	targetConfigs, err := GetPresetTargetConfigs(customPresets)
+	if err != nil {
+		t.Fatalf("GetPresetTargetConfigs() error = %v", err)
+	}
+
+	want := map[string]TargetOptions{
+		"azure-medium": {Region: "centralus", VMSize: "Standard_D4s_v5", DiskType: "Premium_LRS", DiskSize: 64},
+		"azure-large":  {Region: "centralus", VMSize: "Standard_D16s_v5", DiskType: "Premium_LRS", DiskSize: 128},
+		"azure-small":  {Region: "centralus", VMSize: "Standard_B2ms", DiskType: "StandardSSD_LRS", DiskSize: 30},
+		"azure-gpu":    {Region: "eastus", VMSize: "Standard_NC24ads_A100_v4", DiskType: "StandardSSD_LRS", DiskSize: 256},
+	}
+
+	if len(targetConfigs) != len(want) {
+		t.Fatalf("Expected %d preset target configs but got %d", len(want), len(targetConfigs))
+	}
+
+	for _, targetConfig := range targetConfigs {
+		wantOptions, ok := want[targetConfig.Name]
+		if !ok {
+			t.Errorf("Unexpected preset target config %s", targetConfig.Name)
+			continue
+		}
+
+		var got TargetOptions
+		err := json.Unmarshal([]byte(targetConfig.Options), &got)
+		if err != nil {
+			t.Fatalf("Failed to unmarshal options of %s: %v", targetConfig.Name, err)
+		}
+
+		if got.Region != wantOptions.Region || got.VMSize != wantOptions.VMSize ||
+			got.DiskType != wantOptions.DiskType || got.DiskSize != wantOptions.DiskSize {
+			t.Errorf("Preset %s options = %+v, want %+v", targetConfig.Name, got, wantOptions)
+		}
+	}
+}
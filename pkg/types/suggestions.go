@@ -1,11 +1,30 @@
 package types
 
+// Suggestions holds the auto-complete values offered in the target config manifest.
+type Suggestions struct {
+	Regions   []string `json:"regions"`
+	VMSizes   []string `json:"vmSizes"`
+	DiskTypes []string `json:"diskTypes"`
+	ImageURNs []string `json:"imageUrns"`
+}
+
 var (
-	regions = []string{"eastus", "southcentralus", "westus2", "westus3", "australiaeast", "southeastasia", "northeurope", "swedencentral", "uksouth", "westeurope", "centralus", "southafricanorth", "centralindia", "eastasia", "japaneast", "koreacentral", "canadacentral", "francecentral", "germanywestcentral", "italynorth", "norwayeast", "polandcentral", "spaincentral", "switzerlandnorth", "mexicocentral", "uaenorth", "brazilsouth", "israelcentral", "qatarcentral", "centralusstage", "eastusstage", "eastus2stage", "northcentralusstage", "southcentralusstage", "westusstage", "westus2stage", "asia", "asiapacific", "australia", "brazil", "canada", "europe", "france", "germany", "global", "india", "israel", "italy", "japan", "korea", "newzealand", "norway", "poland", "qatar", "singapore", "southafrica", "sweden", "switzerland", "uae", "uk", "unitedstates", "unitedstateseuap", "eastasiastage", "southeastasiastage", "brazilus", "eastus2", "eastusstg", "northcentralus", "westus", "japanwest", "jioindiawest", "centraluseuap", "eastus2euap", "southcentralusstg", "westcentralus", "southafricawest", "australiacentral", "australiacentral2", "australiasoutheast", "jioindiacentral", "koreasouth", "southindia", "westindia", "canadaeast", "francesouth", "germanynorth", "norwaywest", "switzerlandwest", "ukwest", "uaecentral", "brazilsoutheast"}
+	regions = []string{"eastus", "southcentralus", "westus2", "westus3", "australiaeast", "southeastasia", "northeurope", "swedencentral", "uksouth", "westeurope", "centralus", "southafricanorth", "centralindia", "eastasia", "japaneast", "koreacentral", "canadacentral", "francecentral", "germanywestcentral", "italynorth", "norwayeast", "polandcentral", "spaincentral", "switzerlandnorth", "mexicocentral", "uaenorth", "brazilsouth", "israelcentral", "qatarcentral", "eastus2", "northcentralus", "westus", "japanwest", "jioindiawest", "westcentralus", "southafricawest", "australiacentral", "australiacentral2", "australiasoutheast", "jioindiacentral", "koreasouth", "southindia", "westindia", "canadaeast", "francesouth", "germanynorth", "norwaywest", "switzerlandwest", "ukwest", "uaecentral", "brazilsoutheast"}
 
-	vmSizes = []string{"Standard_D64a_v4", "Standard_D96a_v4", "Standard_D2as_v4", "Standard_D4as_v4", "Standard_D8as_v4", "Standard_D16as_v4", "Standard_D32as_v4", "Standard_D48as_v4", "Standard_D64as_v4", "Standard_D96as_v4", "Standard_E2a_v4", "Standard_E4a_v4", "Standard_E8a_v4", "Standard_E16a_v4", "Standard_E20a_v4", "Standard_E32a_v4", "Standard_E48a_v4", "Standard_E64a_v4", "Standard_E96a_v4", "Standard_E2as_v4", "Standard_E4-2as_v4", "Standard_E4as_v4", "Standard_E8-2as_v4", "Standard_E8-4as_v4", "Standard_E8as_v4", "Standard_E16-4as_v4", "Standard_E16-8as_v4", "Standard_E16as_v4", "Standard_E20as_v4", "Standard_E32-8as_v4", "Standard_E32-16as_v4", "Standard_E32as_v4", "Standard_E48as_v4", "Standard_E64-16as_v4", "Standard_E64-32as_v4", "Standard_E64as_v4", "Standard_E96-24as_v4", "Standard_E96-48as_v4", "Standard_E96as_v4", "Standard_D2as_v5", "Standard_D4as_v5", "Standard_D8as_v5", "Standard_D16as_v5", "Standard_D32as_v5", "Standard_D48as_v5", "Standard_D64as_v5", "Standard_D96as_v5", "Standard_E2as_v5", "Standard_E4-2as_v5", "Standard_E4as_v5", "Standard_E8-2as_v5", "Standard_E8-4as_v5", "Standard_E8as_v5", "Standard_E16-4as_v5", "Standard_E16-8as_v5", "Standard_E16as_v5", "Standard_E20as_v5", "Standard_E32-8as_v5", "Standard_E32-16as_v5", "Standard_E32as_v5", "Standard_E48as_v5", "Standard_E64-16as_v5", "Standard_E64-32as_v5", "Standard_E64as_v5", "Standard_E96-24as_v5", "Standard_E96-48as_v5", "Standard_E96as_v5", "Standard_D2ads_v5", "Standard_D4ads_v5", "Standard_D8ads_v5", "Standard_D16ads_v5", "Standard_D32ads_v5", "Standard_D48ads_v5", "Standard_D64ads_v5", "Standard_D96ads_v5", "Standard_E2ads_v5", "Standard_E4-2ads_v5", "Standard_E4ads_v5", "Standard_E8-2ads_v5", "Standard_E8-4ads_v5", "Standard_E8ads_v5", "Standard_E16-4ads_v5", "Standard_E16-8ads_v5", "Standard_E16ads_v5", "Standard_E20ads_v5", "Standard_E32-8ads_v5", "Standard_E32-16ads_v5", "Standard_E32ads_v5", "Standard_E48ads_v5", "Standard_E64-16ads_v5", "Standard_E64-32ads_v5", "Standard_E64ads_v5", "Standard_E96-24ads_v5", "Standard_E96-48ads_v5", "Standard_E96ads_v5", "Standard_A0", "Standard_A1", "Standard_A2", "Standard_A3", "Standard_A5", "Standard_A4", "Standard_A6", "Standard_A7", "Basic_A0", "Basic_A1", "Basic_A2", "Basic_A3", "Basic_A4", "Standard_D1_v2", "Standard_D2_v2", "Standard_D3_v2", "Standard_D4_v2", "Standard_D5_v2", "Standard_D11_v2", "Standard_D12_v2", "Standard_D13_v2", "Standard_D14_v2", "Standard_D15_v2", "Standard_D2_v2_Promo", "Standard_D3_v2_Promo", "Standard_D4_v2_Promo", "Standard_D5_v2_Promo", "Standard_D11_v2_Promo", "Standard_D12_v2_Promo", "Standard_D13_v2_Promo", "Standard_D14_v2_Promo", "Standard_F1", "Standard_F2", "Standard_F4", "Standard_F8", "Standard_F16", "Standard_A1_v2", "Standard_A2m_v2", "Standard_A2_v2", "Standard_A4m_v2", "Standard_A4_v2", "Standard_A8m_v2", "Standard_A8_v2", "Standard_DS1", "Standard_DS2", "Standard_DS3", "Standard_DS4", "Standard_DS11", "Standard_DS12", "Standard_DS13", "Standard_DS14", "Standard_L8s_v3", "Standard_L16s_v3", "Standard_L32s_v3", "Standard_L48s_v3", "Standard_L64s_v3", "Standard_L80s_v3", "Standard_E2ds_v4", "Standard_E4-2ds_v4", "Standard_E4ds_v4", "Standard_E8-2ds_v4", "Standard_E8-4ds_v4", "Standard_E8ds_v4", "Standard_E16-4ds_v4", "Standard_E16-8ds_v4", "Standard_E16ds_v4", "Standard_E20ds_v4", "Standard_E32-8ds_v4", "Standard_E32-16ds_v4", "Standard_E32ds_v4", "Standard_E48ds_v4", "Standard_E64-16ds_v4", "Standard_E64-32ds_v4", "Standard_E64ds_v4", "Standard_E2ds_v5", "Standard_E4-2ds_v5", "Standard_E4ds_v5", "Standard_E8-2ds_v5", "Standard_E8-4ds_v5", "Standard_E8ds_v5", "Standard_E16-4ds_v5", "Standard_E16-8ds_v5", "Standard_E16ds_v5", "Standard_E20ds_v5", "Standard_E32-8ds_v5", "Standard_E32-16ds_v5", "Standard_E32ds_v5", "Standard_E48ds_v5", "Standard_E64-16ds_v5", "Standard_E64-32ds_v5", "Standard_E64ds_v5", "Standard_E96-24ds_v5", "Standard_E96-48ds_v5", "Standard_E96ds_v5", "Standard_E104ids_v5", "Standard_E2"}
+	vmSizes = []string{"Standard_D64a_v4", "Standard_D96a_v4", "Standard_D2as_v4", "Standard_D4as_v4", "Standard_D8as_v4", "Standard_D16as_v4", "Standard_D32as_v4", "Standard_D48as_v4", "Standard_D64as_v4", "Standard_D96as_v4", "Standard_E2a_v4", "Standard_E4a_v4", "Standard_E8a_v4", "Standard_E16a_v4", "Standard_E20a_v4", "Standard_E32a_v4", "Standard_E48a_v4", "Standard_E64a_v4", "Standard_E96a_v4", "Standard_E2as_v4", "Standard_E4-2as_v4", "Standard_E4as_v4", "Standard_E8-2as_v4", "Standard_E8-4as_v4", "Standard_E8as_v4", "Standard_E16-4as_v4", "Standard_E16-8as_v4", "Standard_E16as_v4", "Standard_E20as_v4", "Standard_E32-8as_v4", "Standard_E32-16as_v4", "Standard_E32as_v4", "Standard_E48as_v4", "Standard_E64-16as_v4", "Standard_E64-32as_v4", "Standard_E64as_v4", "Standard_E96-24as_v4", "Standard_E96-48as_v4", "Standard_E96as_v4", "Standard_D2as_v5", "Standard_D4as_v5", "Standard_D8as_v5", "Standard_D16as_v5", "Standard_D32as_v5", "Standard_D48as_v5", "Standard_D64as_v5", "Standard_D96as_v5", "Standard_E2as_v5", "Standard_E4-2as_v5", "Standard_E4as_v5", "Standard_E8-2as_v5", "Standard_E8-4as_v5", "Standard_E8as_v5", "Standard_E16-4as_v5", "Standard_E16-8as_v5", "Standard_E16as_v5", "Standard_E20as_v5", "Standard_E32-8as_v5", "Standard_E32-16as_v5", "Standard_E32as_v5", "Standard_E48as_v5", "Standard_E64-16as_v5", "Standard_E64-32as_v5", "Standard_E64as_v5", "Standard_E96-24as_v5", "Standard_E96-48as_v5", "Standard_E96as_v5", "Standard_D2ads_v5", "Standard_D4ads_v5", "Standard_D8ads_v5", "Standard_D16ads_v5", "Standard_D32ads_v5", "Standard_D48ads_v5", "Standard_D64ads_v5", "Standard_D96ads_v5", "Standard_E2ads_v5", "Standard_E4-2ads_v5", "Standard_E4ads_v5", "Standard_E8-2ads_v5", "Standard_E8-4ads_v5", "Standard_E8ads_v5", "Standard_E16-4ads_v5", "Standard_E16-8ads_v5", "Standard_E16ads_v5", "Standard_E20ads_v5", "Standard_E32-8ads_v5", "Standard_E32-16ads_v5", "Standard_E32ads_v5", "Standard_E48ads_v5", "Standard_E64-16ads_v5", "Standard_E64-32ads_v5", "Standard_E64ads_v5", "Standard_E96-24ads_v5", "Standard_E96-48ads_v5", "Standard_E96ads_v5", "Standard_D1_v2", "Standard_D2_v2", "Standard_D3_v2", "Standard_D4_v2", "Standard_D5_v2", "Standard_D11_v2", "Standard_D12_v2", "Standard_D13_v2", "Standard_D14_v2", "Standard_D15_v2", "Standard_F1", "Standard_F2", "Standard_F4", "Standard_F8", "Standard_F16", "Standard_A1_v2", "Standard_A2m_v2", "Standard_A2_v2", "Standard_A4m_v2", "Standard_A4_v2", "Standard_A8m_v2", "Standard_A8_v2", "Standard_DS1", "Standard_DS2", "Standard_DS3", "Standard_DS4", "Standard_DS11", "Standard_DS12", "Standard_DS13", "Standard_DS14", "Standard_L8s_v3", "Standard_L16s_v3", "Standard_L32s_v3", "Standard_L48s_v3", "Standard_L64s_v3", "Standard_L80s_v3", "Standard_E2ds_v4", "Standard_E4-2ds_v4", "Standard_E4ds_v4", "Standard_E8-2ds_v4", "Standard_E8-4ds_v4", "Standard_E8ds_v4", "Standard_E16-4ds_v4", "Standard_E16-8ds_v4", "Standard_E16ds_v4", "Standard_E20ds_v4", "Standard_E32-8ds_v4", "Standard_E32-16ds_v4", "Standard_E32ds_v4", "Standard_E48ds_v4", "Standard_E64-16ds_v4", "Standard_E64-32ds_v4", "Standard_E64ds_v4", "Standard_E2ds_v5", "Standard_E4-2ds_v5", "Standard_E4ds_v5", "Standard_E8-2ds_v5", "Standard_E8-4ds_v5", "Standard_E8ds_v5", "Standard_E16-4ds_v5", "Standard_E16-8ds_v5", "Standard_E16ds_v5", "Standard_E20ds_v5", "Standard_E32-8ds_v5", "Standard_E32-16ds_v5", "Standard_E32ds_v5", "Standard_E48ds_v5", "Standard_E64-16ds_v5", "Standard_E64-32ds_v5", "Standard_E64ds_v5", "Standard_E96-24ds_v5", "Standard_E96-48ds_v5", "Standard_E96ds_v5", "Standard_E104ids_v5"}
 
-	diskTypes = []string{"Aligned", "Classic", "PremiumV2_LRS", "Premium_LRS", "StandardSSD_LRS", "Standard_LRS", "UltraSSD_LRS", "DADSv5-Type1", "DASv4-Type1", "DASv4-Type2", "DASv5-Type1", "DCdsv3-Type1", "DCsv3-Type1", "DDSv4-Type1", "DDSv4-Type2", "DDSv5-Type1", "DSv3-Type3", "DSv3-Type4", "DSv4-Type1", "DSv4-Type2", "DSv5-Type1", "EADSv5-Type1", "EASv4-Type1", "EASv4-Type2", "EASv5-Type1", "Ebdsv5-Type1", "Ebsv5-Type1", "EDSv4-Type1", "EDSv4-Type2", "EDSv5-Type1", "ESv3-Type3", "ESv3-Type4", "ESv4-Type1", "ESv4-Type2", "ESv5-Type1", "FSv2-Type2", "FSv2-Type3", "FSv2-Type4", "Lasv3-Type1", "LSv2-Type1", "Lsv3-Type1", "Mdmsv2MedMem-Type1", "Mdsv2MedMem-Type1", "Mmsv2MedMem-Type1", "MS-Type1", "MSm-Type1", "MSmv2-Type1", "MSv2-Type1", "Msv2MedMem-Type1", "Standard_ZRS", "Standard_A1_v2", "Standard_A2m_v2", "Standard_A2_v2", "Standard_A4m_v2", "Standard_A4_v2", "Standard_A8m_v2", "Standard_A8_v2", "Standard_B12ms", "Standard_B16als_v2", "Standard_B16as_v2", "Standard_B16ls_v2", "Standard_B16ms", "Standard_B16pls_v2", "Standard_B16ps_v2", "Standard_B16s_v2", "Standard_B1ls", "Standard_B1ms", "Standard_B1s", "Standard_B20ms", "Standard_B2als_v2", "Standard_B2as_v2", "Standard_B2ats_v2", "Standard_B2ls_v2", "Standard_B2ms", "Standard_B2pls_v2", "Standard_B2ps_v2", "Standard_B2pts_v2", "Standard_B2s", "Standard_B2s_v2", "Standard_B2ts_v2", "Standard_B32als_v2", "Standard_B32as_v2", "Standard_B32ls_v2", "Standard_B32s_v2", "Standard_B4als_v2", "Standard_B4as_v2", "Standard_B4ls_v2", "Standard_B4ms", "Standard_B4pls_v2", "Standard_B4ps_v2", "Standard_B4s_v2", "Standard_B8als_v2", "Standard_B8as_v2", "Standard_B8ls_v2", "Standard_B8ms", "Standard_B8pls_v2", "Standard_B8ps_v2", "Standard_B8s_v2", "Standard_D11_v2", "Standard_D12_v2", "Standard_D13_v2", "Standard_D14_v2", "Standard_D15_v2", "Standard_D16ads_v5", "Standard_D16as_v4", "Standard_D16as_v5", "Standard_D16a_v4", "Standard_D16ds_v4", "Standard_D16ds_v5", "Standard_D16d_v4", "Standard_D16d_v5", "Standard_D16lds_v5", "Standard_D16ls_v5", "Standard_D16s_v3", "Standard_D16s_v4", "Standard_D16s_v5", "Standard_D16_v3", "Standard_D16_v4", "Standard_D16_v5", "Standard_D1_v2", "Standard_D2ads_v5", "Standard_D2as_v4", "Standard_D2as_v5", "Standard_D2a_v4", "Standard_D2ds_v4", "Standard_D2ds_v5", "Standard_D2d_v4", "Standard_D2d_v5", "Standard_D2lds_v5", "Standard_D2ls_v5", "Standard_D2s_v3", "Standard_D2s_v4", "Standard_D2s_v5", "Standard_D2_v2", "Standard_D2_v3", "Standard_D2_v4", "Standard_D2_v5", "Standard_D32ads_v5", "Standard_D32as_v4", "Standard_D32as_v5", "Standard_D32a_v4", "Standard_D32ds_v4", "Standard_D32ds_v5", "Standard_D32d_v4", "Standard_D32d_v5", "Standard_D32lds_v5", "Standard_D32ls_v5", "Standard_D32s_v3", "Standard_D32s_v4", "Standard_D32s_v5", "Standard_D32_v3", "Standard_D32_v4", "Standard_D32_v5", "Standard_D3_v2", "Standard_D48ads_v5", "Standard_D48as_v4", "Standard_D48as_v5", "Standard_D48a_v4", "Standard_D48ds_v4", "Standard_D48ds_v5", "Standard_D48d_v4", "Standard_D48d_v5", "Standard_D48lds_v5", "Standard_D48ls_v5", "Standard_D48s_v3", "Standard_D48s_v4", "Standard_D48s_v5", "Standard_D48_v3", "Standard_D48_v4", "Standard_D48_v5", "Standard_D4ads_v5", "Standard_D4as_v4", "Standard_D4as_v5", "Standard_D4a_v4", "Standard_D4ds_v4", "Standard_D4ds_v5", "Standard_D4d_v4", "Standard_D4d_v5", "Standard_D4lds_v5", "Standard_D4ls_v5", "Standard_D4s_v3", "Standard_D4s_v4", "Standard_D4s_v5", "Standard_D4_v2", "Standard_D4_v3", "Standard_D4_v4", "Standard_D4_v5", "Standard_D5_v2", "Standard_D64ads_v5", "Standard_D64as_v4", "Standard_D64as_v5", "Standard_D64a_v4", "Standard_D64ds_v4", "Standard_D64ds_v5", "Standard_D64d_v4", "Standard_D64d_v5", "Standard_D64lds_v5", "Standard_D64ls_v5", "Standard_D64s_v3", "Standard_D64s_v4", "Standard_D64s_v5", "Standard_D64_v3", "Standard_D64_v4", "Standard_D64_v5", "Standard_D8ads_v5", "Standard_D8as_v4", "Standard_D8as_v5", "Standard_D8a_v4", "Standard_D8ds_v4", "Standard_D8ds_v5", "Standard_D8d_v4", "Standard_D8d_v5", "Standard_D8lds_v5", "Standard_D8ls_v5", "Standard_D8s_v3", "Standard_D8s_v4", "Standard_D8s_v5", "Standard_D8_v3", "Standard_D8_v4", "Standard_D8_v5", "Standard_D96ads_v5", "Standard_D96as_v4", "Standard_D96as_v5", "Standard_D96a_v4", "Standard_D96ds_v5", "Standard_D96d_v5", "Standard_D96lds_v5", "Standard_D96ls_v5", "Standard_D96s_v5", "Standard_D96_v5", "Standard_DC16eds_v5", "Standard_DC16es_v5", "Standard_DC2eds_v5", "Standard_DC2es_v5", "Standard_DC32eds_v5", "Standard_DC32es_v5", "Standard_DC48eds_v5", "Standard_DC48es_v5", "Standard_DC4eds_v5", "Standard_DC4es_v5", "Standard_DC64eds_v5", "Standard_DC64es_v5", "Standard_DC8eds_v5", "Standard_DC8es_v5", "Standard_DC96eds_v5", "Standard_DC96es_v5", "Standard_DS11-1_v2", "Standard_DS11_v2", "Standard_DS12-1_v2", "Standard_DS12-2_v2", "Standard_DS12_v2", "Standard_DS13-2_v2", "Standard_DS13-4_v2", "Standard_DS13_v2", "Standard_DS14-4_v2", "Standard_DS14-8_v2", "Standard_DS14_v2", "Standard_DS15_v2", "Standard_DS1_v2", "Standard_DS2_v2", "Standard_DS3_v2", "Standard_DS4_v2", "Standard_DS5_v2", "Standard_E104ids_v5", "Standard_E104id_v5", "Standard_E104is_v5", "Standard_E104i_v5", "Standard_E112iads_v5", "Standard_E112ias_v5", "Standard_E112ibds_v5", "Standard_E112ibs_v5", "Standard_E16-4ads_v5", "Standard_E16-4as_v4", "Standard_E16-4as_v5", "Standard_E16-4ds_v4", "Standard_E16-4ds_v5", "Standard_E16-4s_v3", "Standard_E16-4s_v4", "Standard_E16-4s_v5", "Standard_E16-8ads_v5", "Standard_E16-8as_v4", "Standard_E16-8as_v5", "Standard_E16-8ds_v4", "Standard_E16-8ds_v5", "Standard_E16-8s_v3", "Standard_E16-8s_v4", "Standard_E16-8s_v5", "Standard_E16ads_v5", "Standard_E16as_v4", "Standard_E16as_v5", "Standard_E16a_v4", "Standard_E16bds_v5", "Standard_E16bs_v5", "Standard_E16ds_v4", "Standard_E16ds_v5", "Standard_E16d_v4", "Standard_E16d_v5", "Standard_E16s_v3", "Standard_E16s_v4", "Standard_E16s_v5", "Standard_E16_v3", "Standard_E16_v4", "Standard_E16_v5", "Standard_E20ads_v5", "Standard_E20as_v4", "Standard_E20as_v5", "Standard_E20a_v4", "Standard_E20ds_v4", "Standard_E20ds_v5", "Standard_E20d_v4", "Standard_E20d_v5", "Standard_E20s_v3", "Standard_E20s_v4", "Standard_E20s_v5", "Standard_E20_v3", "Standard_E20_v4", "Standard_E20_v5", "Standard_E2ads_v5", "Standard_E2as_v4", "Standard_E2as_v5", "Standard_E2a_v4", "Standard_E2bds_v5", "Standard_E2bs_v5", "Standard_E2ds_v4", "Standard_E2ds_v5", "Standard_E2d_v4", "Standard_E2d_v5", "Standard_E2s_v3", "Standard_E2s_v4", "Standard_E2s_v5", "Standard_E2_v3", "Standard_E2_v4", "Standard_E2_v5", "Standard_E32-16ads_v5", "Standard_E32-16as_v4", "Standard_E32-16as_v5", "Standard_E32-16ds_v4", "Standard_E32-16ds_v5", "Standard_E32-16s_v3", "Standard_E32-16s_v4", "Standard_E32-16s_v5", "Standard_E32-8ads_v5", "Standard_E32-8as_v4", "Standard_E32-8as_v5", "Standard_E32-8ds_v4", "Standard_E32-8ds_v5", "Standard_E32-8s_v3", "Standard_E32-8s_v4", "Standard_E32-8s_v5", "Standard_E32ads_v5", "Standard_E32as_v4", "Standard_E32as_v5", "Standard_E32a_v4", "Standard_E32bds_v5", "Standard_E32bs_v5", "Standard_E32ds_v4", "Standard_E32ds_v5", "Standard_E32d_v4", "Standard_E32d_v5", "Standard_E32s_v3", "Standard_E32s_v4", "Standard_E32s_v5", "Standard_E32_v3", "Standard_E32_v4", "Standard_E32_v5", "Standard_E4-2ads_v5", "Standard_E4-2as_v4", "Standard_E4-2as_v5", "Standard_E4-2ds_v4", "Standard_E4-2ds_v5", "Standard_E4-2s_v3", "Standard_E4-2s_v4", "Standard_E4-2s_v5", "Standard_E48ads_v5", "Standard_E48as_v4", "Standard_E48as_v5", "Standard_E48a_v4", "Standard_E48bds_v5", "Standard_E48bs_v5", "Standard_E48ds_v4", "Standard_E48ds_v5", "Standard_E48d_v4", "Standard_E48d_v5", "Standard_E48s_v3", "Standard_E48s_v4", "Standard_E48s_v5", "Standard_E48_v3", "Standard_E48_v4", "Standard_E48_v5", "Standard_E4ads_v5", "Standard_E4as_v4", "Standard_E4as_v5", "Standard_E4a_v4", "Standard_E4bds_v5", "Standard_E4bs_v5", "Standard_E4ds_v4", "Standard_E4ds_v5", "Standard_E4d_v4", "Standard_E4d_v5", "Standard_E4s_v3", "Standard_E4s_v4", "Standard_E4s_v5", "Standard_E4_v3", "Standard_E4_v4", "Standard_E4_v5", "Standard_E64-16ads_v5", "Standard_E64-16as_v4", "Standard_E64-16as_v5", "Standard_E64-16ds_v4", "Standard_E64-16ds_v5", "Standard_E64-16s_v3", "Standard_E64-16s_v4", "Standard_E64-16s_v5", "Standard_E64-32ads_v5", "Standard_E64-32as_v4", "Standard_E64-32as_v5", "Standard_E64-32ds_v4", "Standard_E64-32ds_v5", "Standard_E64-32s_v3", "Standard_E64-32s_v4", "Standard_E64-32s_v5", "Standard_E64ads_v5", "Standard_E64as_v4", "Standard_E64as_v5", "Standard_E64a_v4", "Standard_E64bds_v5", "Standard_E64bs_v5", "Standard_E64ds_v4", "Standard_E64ds_v5", "Standard_E64d_v4", "Standard_E64d_v5", "Standard_E64is_v3", "Standard_E64i_v3", "Standard_E64s_v3", "Standard_E64s_v4", "Standard_E64s_v5", "Standard_E64_v3", "Standard_E64_v4", "Standard_E64_v5", "Standard_E8-2ads_v5", "Standard_E8-2as_v4", "Standard_E8-2as_v5", "Standard_E8-2ds_v4", "Standard_E8-2ds_v5", "Standard_E8-2s_v3", "Standard_E8-2s_v4", "Standard_E8-2s_v5", "Standard_E8-4ads_v5", "Standard_E8-4as_v4", "Standard_E8-4as_v5", "Standard_E8-4ds_v4", "Standard_E8-4ds_v5", "Standard_E8-4s_v3", "Standard_E8-4s_v4", "Standard_E8-4s_v5", "Standard_E80ids_v4", "Standard_E80is_v4", "Standard_E8ads_v5", "Standard_E8as_v4", "Standard_E8as_v5", "Standard_E8a_v4", "Standard_E8bds_v5", "Standard_E8bs_v5", "Standard_E8ds_v4", "Standard_E8ds_v5", "Standard_E8d_v4", "Standard_E8d_v5", "Standard_E8s_v3", "Standard_E8s_v4", "Standard_E8s_v5", "Standard_E8_v3", "Standard_E8_v4", "Standard_E8_v5", "Standard_E96-24ads_v5", "Standard_E96-24as_v4", "Standard_E96-24as_v5", "Standard_E96-24ds_v5", "Standard_E96-24s_v5", "Standard_E96-48ads_v5", "Standard_E96-48as_v4", "Standard_E96-48as_v5", "Standard_E96-48ds_v5", "Standard_E96-48s_v5", "Standard_E96ads_v5", "Standard_E96as_v4", "Standard_E96as_v5", "Standard_E96a_v4", "Standard_E96bds_v5", "Standard_E96bs_v5", "Standard_E96ds_v5", "Standard_E96d_v5", "Standard_E96ias_v4", "Standard_E96s_v5", "Standard_E96_v5", "Standard_EC128eds_v5", "Standard_EC128es_v5", "Standard_EC128ieds_v5", "Standard_EC128ies_v5", "Standard_EC16eds_v5", "Standard_EC16es_v5", "Standard_EC2eds_v5", "Standard_EC2es_v5", "Standard_EC32eds_v5", "Standard_EC32es_v5", "Standard_EC48eds_v5", "Standard_EC48es_v5", "Standard_EC4eds_v5", "Standard_EC4es_v5", "Standard_EC64eds_v5", "Standard_EC64es_v5", "Standard_EC8eds_v5", "Standard_EC8es_v5", "Standard_F1", "Standard_F16", "Standard_F16s", "Standard_F16s_v2", "Standard_F1s", "Standard_F2", "Standard_F2s", "Standard_F2s_v2", "Standard_F32s_v2", "Standard_F4", "Standard_F48s_v2", "Standard_F4s", "Standard_F4s_v2", "Standard_F64s_v2", "Standard_F72s_v2", "Standard_F8", "Standard_F8s", "Standard_F8s_v2", "Standard_L16as_v3", "Standard_L16s_v3", "Standard_L32as_v3", "Standard_L32s_v3", "Standard_L48as_v3", "Standard_L48s_v3", "Standard_L64as_v3", "Standard_L64s_v3", "Standard_L80as_v3", "Standard_L80s_v3", "Standard_L8as_v3", "Standard_L8s_v3", "Standard_M128", "Standard_M128-32ms", "Standard_M128-64ms", "Standard_M128dms_v2", "Standard_M128ds_v2", "Standard_M128m", "Standard_M128ms", "Standard_M128ms_v2", "Standard_M128s", "Standard_M128s_v2", "Standard_M12ds_v3", "Standard_M12s_v3", "Standard_M16-4ms", "Standard_M16-8ms", "Standard_M16ms", "Standard_M176ds_3_v3", "Standard_M176ds_4_v3", "Standard_M176s_3_v3", "Standard_M176s_4_v3", "Standard_M192idms_v2", "Standard_M192ids_v2", "Standard_M192ims_v2", "Standard_M192is_v2", "Standard_M208ms_v2", "Standard_M208s_v2", "Standard_M24ds_v3", "Standard_M24s_v3", "Standard_M32-16ms", "Standard_M32-8ms", "Standard_M32dms_v2", "Standard_M32ls", "Standard_M32ms", "Standard_M32ms_v2", "Standard_M32ts", "Standard_M416-208ms_v2", "Standard_M416-208s_v2", "Standard_M416ms_v2", "Standard_M416s_8_v2", "Standard_M416s_v2", "Standard_M48ds_1_v3", "Standard_M48s_1_v3", "Standard_M64", "Standard_M64-16ms", "Standard_M64-32ms", "Standard_M64dms_v2", "Standard_M64ds_v2", "Standard_M64ls", "Standard_M64m", "Standard_M64ms", "Standard_M64ms_v2", "Standard_M64s", "Standard_M64s_v2", "Standard_M8-2ms", "Standard_M8-4ms", "Standard_M8ms", "Standard_M96ds_1_v3", "Standard_M96ds_2_v3", "Standard_M96s_1_v3", "Standard_M96s_2_v3", "Standard_NC24ads_A100_v4", "Standard_NC48ads_A100_v4", "Standard_NC96ads_A100_v4"}
+	diskTypes = []string{"Standard_LRS", "StandardSSD_LRS", "StandardSSD_ZRS", "Premium_LRS", "Premium_ZRS"}
 
 	imagesUrns = []string{"Canonical:ubuntu-24_04-lts:server:latest", "OpenLogic:CentOS:8_5-gen2:latest", "Debian:debian-11:11-backports-gen2:latest", "kinvolk:flatcar-container-linux-free:stable-gen2:latest", "SUSE:openSUSE-leap-15-4:gen2:latest", "RedHat:RHEL:8-lvm-gen2:latest", "SUSE:sles-15-sp3:gen2:latest", "Canonical:0001-com-ubuntu-server-jammy:22_04-lts-gen2:latest"}
 )
+
+// GetStaticSuggestions returns the built-in suggestions, used when the subscription
+// cannot be queried for its locations and resource SKUs.
+func GetStaticSuggestions() *Suggestions {
+	return &Suggestions{
+		Regions:   regions,
+		VMSizes:   withCatalogueVMSizes(vmSizes),
+		DiskTypes: diskTypes,
+		ImageURNs: imagesUrns,
+	}
+}
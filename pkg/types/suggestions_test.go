@@ -0,0 +1,17 @@
+package types
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestStaticVMSizesAreDeployable(t *testing.T) {
+	retiredASeries := regexp.MustCompile(`^Standard_A\d+$`)
+
+	for _, vmSize := range GetStaticSuggestions().VMSizes {
+		if strings.HasPrefix(vmSize, "Basic_") || strings.HasSuffix(vmSize, "_Promo") || retiredASeries.MatchString(vmSize) {
+			t.Errorf("static suggestions include retired VM size %s", vmSize)
+		}
+	}
+}
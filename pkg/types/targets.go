@@ -3,32 +3,200 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"net/netip"
+	"net/url"
 	"os"
+	"regexp"
+	"strings"
+	"unicode"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/daytonaio/daytona/pkg/models"
+	"golang.org/x/crypto/ssh"
 )
 
+const (
+	AuthMethodClientSecret      = "ClientSecret"
+	AuthMethodClientCertificate = "ClientCertificate"
+	AuthMethodWorkloadIdentity  = "WorkloadIdentity"
+	AuthMethodManagedIdentity   = "ManagedIdentity"
+	AuthMethodAzureCLI          = "AzureCLI"
+	AuthMethodEnvironment       = "Environment"
+	AuthMethodDefault           = "Default"
+)
+
+const (
+	CloudAzurePublic     = "AzurePublic"
+	CloudAzureGovernment = "AzureGovernment"
+	CloudAzureChina      = "AzureChina"
+	CloudCustom          = "Custom"
+)
+
+const (
+	NetworkModePerTarget = "PerTarget"
+	NetworkModeShared    = "Shared"
+)
+
+const (
+	PublicIPNone   = "None"
+	PublicIPStatic = "Static"
+)
+
+const (
+	NATGatewayNone   = "None"
+	NATGatewayCreate = "Create"
+)
+
+// DefaultRegion is the default value of the Region option.
+const DefaultRegion = "centralus"
+
+// AvailabilityZoneAny tries the availability zones that offer the VM size one by one.
+const AvailabilityZoneAny = "any"
+
+var availabilityZonePattern = regexp.MustCompile(`^[1-9]$`)
+
+const (
+	SecurityTypeStandard       = "Standard"
+	SecurityTypeTrustedLaunch  = "TrustedLaunch"
+	SecurityTypeConfidentialVM = "ConfidentialVM"
+)
+
+const (
+	PriorityRegular = "Regular"
+	PrioritySpot    = "Spot"
+)
+
+const (
+	EvictionPolicyDeallocate = "Deallocate"
+	EvictionPolicyDelete     = "Delete"
+)
+
+// NoMaxPrice caps the price of Spot virtual machines at the pay-as-you-go price, so they
+// are not evicted for price reasons.
+const NoMaxPrice = -1
+
+const (
+	EgressPolicyAllowAll  = "AllowAll"
+	EgressPolicyAllowList = "AllowList"
+)
+
+var dnsLabelPattern = regexp.MustCompile(`^[a-z][a-z0-9-]{1,61}[a-z0-9]$`)
+
 type TargetOptions struct {
-	Region         string `json:"Region"`
-	TenantId       string `json:"Tenant Id"`
-	ClientId       string `json:"Client Id"`
-	ClientSecret   string `json:"Client Secret"`
-	SubscriptionId string `json:"Subscription Id"`
-	ResourceGroup  string `json:"Resource Group"`
-	ImageURN       string `json:"Image URN"`
-	VMSize         string `json:"VM Size"`
-	DiskType       string `json:"Disk Type"`
-	DiskSize       int    `json:"Disk Size"`
+	Region                    string  `json:"Region"`
+	AvailabilityZone          string  `json:"Availability Zone"`
+	Cloud                     string  `json:"Cloud"`
+	ARMEndpoint               string  `json:"ARM Endpoint"`
+	AuthorityHost             string  `json:"Authority Host"`
+	ARMAudience               string  `json:"ARM Audience"`
+	AuthMethod                string  `json:"Auth Method"`
+	TenantId                  string  `json:"Tenant Id"`
+	ClientId                  string  `json:"Client Id"`
+	ClientSecret              string  `json:"Client Secret"`
+	ClientCertificate         string  `json:"Client Certificate"`
+	ClientCertificatePassword string  `json:"Client Certificate Password"`
+	FederatedTokenFile        string  `json:"Federated Token File"`
+	SubscriptionId            string  `json:"Subscription Id"`
+	ResourceGroup             string  `json:"Resource Group"`
+	ImageURN                  string  `json:"Image URN"`
+	VMSize                    string  `json:"VM Size"`
+	DiskType                  string  `json:"Disk Type"`
+	DiskSize                  int     `json:"Disk Size"`
+	Priority                  string  `json:"Priority"`
+	EvictionPolicy            string  `json:"Eviction Policy"`
+	MaxPrice                  float64 `json:"Max Price"`
+	SecurityType              string  `json:"Security Type"`
+	SecureBoot                *bool   `json:"Secure Boot"`
+	VTPM                      *bool   `json:"vTPM"`
+	NetworkMode               string  `json:"Network Mode"`
+	SubnetId                  string  `json:"Subnet ID"`
+	InboundRules              string  `json:"Inbound Rules"`
+	PublicIP                  string  `json:"Public IP"`
+	PublicIPDNSLabel          string  `json:"Public IP DNS Label"`
+	NATGateway                string  `json:"NAT Gateway"`
+	NATGatewayId              string  `json:"NAT Gateway ID"`
+	AcceleratedNetworking     bool    `json:"Accelerated Networking"`
+	AddressSpace              string  `json:"Address Space"`
+	SubnetPrefix              string  `json:"Subnet Prefix"`
+	DNSServers                string  `json:"DNS Servers"`
+	PrivateDNSZoneId          string  `json:"Private DNS Zone ID"`
+	HTTPProxy                 string  `json:"HTTP Proxy"`
+	HTTPSProxy                string  `json:"HTTPS Proxy"`
+	NoProxy                   string  `json:"No Proxy"`
+	CABundle                  string  `json:"CA Bundle"`
+	EgressPolicy              string  `json:"Egress Policy"`
+	EgressAllowList           string  `json:"Egress Allow List"`
+	SSHPublicKey              string  `json:"SSH Public Key"`
+	SSHKeyPassphrase          string  `json:"SSH Key Passphrase"`
 }
 
+// GetTargetConfigManifest returns the target config manifest with the built-in suggestions.
 func GetTargetConfigManifest() *models.TargetConfigManifest {
+	return GetTargetConfigManifestWithSuggestions(GetStaticSuggestions())
+}
+
+// GetTargetConfigManifestWithSuggestions returns the target config manifest with the given
+// auto-complete suggestions for regions, VM sizes, disk types and image URNs.
+func GetTargetConfigManifestWithSuggestions(suggestions *Suggestions) *models.TargetConfigManifest {
 	return &models.TargetConfigManifest{
 		"Region": models.TargetConfigProperty{
 			Type:         models.TargetConfigPropertyTypeString,
-			DefaultValue: "centralus",
-			Description: "The geographic area where Azure resources are hosted. Default is centralus.\n" +
+			DefaultValue: DefaultRegion,
+			Description: "The geographic area where Azure resources are hosted. Default is " + DefaultRegion + ".\n" +
 				"List of available regions can be retrieved using the command:\n\"az account list-locations -o table\"",
-			Suggestions: regions,
+			Suggestions: suggestions.Regions,
+		},
+		"Availability Zone": models.TargetConfigProperty{
+			Type: models.TargetConfigPropertyTypeString,
+			Description: "The availability zone of the virtual machine, e.g. 1. Leave blank for a regional virtual machine.\n" +
+				"\"any\" tries the zones that offer the VM size one by one until one has capacity.",
+			Suggestions: []string{AvailabilityZoneAny, "1", "2", "3"},
+		},
+		"Cloud": models.TargetConfigProperty{
+			Type:         models.TargetConfigPropertyTypeOption,
+			DefaultValue: CloudAzurePublic,
+			Description: "The Azure cloud to use. Default is AzurePublic.\n" +
+				"Select Custom to set the ARM Endpoint, Authority Host and ARM Audience explicitly, e.g. for Azure Stack Hub.",
+			Options: []string{
+				CloudAzurePublic,
+				CloudAzureGovernment,
+				CloudAzureChina,
+				CloudCustom,
+			},
+		},
+		"ARM Endpoint": models.TargetConfigProperty{
+			Type:        models.TargetConfigPropertyTypeString,
+			Description: "The Azure Resource Manager endpoint, e.g. https://management.local.azurestack.external/. Only used with the Custom cloud.",
+		},
+		"Authority Host": models.TargetConfigProperty{
+			Type:        models.TargetConfigPropertyTypeString,
+			Description: "The Microsoft Entra ID authority host, e.g. https://login.microsoftonline.com/. Only used with the Custom cloud.",
+		},
+		"ARM Audience": models.TargetConfigProperty{
+			Type: models.TargetConfigPropertyTypeString,
+			Description: "The audience requested for Azure Resource Manager tokens. Only used with the Custom cloud.\n" +
+				"Leave blank to use the ARM Endpoint.",
+		},
+		"Auth Method": models.TargetConfigProperty{
+			Type:         models.TargetConfigPropertyTypeOption,
+			DefaultValue: AuthMethodClientSecret,
+			Description: "The method used to authenticate with Azure. Default is ClientSecret.\n" +
+				"ClientSecret requires Tenant Id, Client Id and Client Secret.\n" +
+				"ClientCertificate requires Tenant Id, Client Id and Client Certificate.\n" +
+				"WorkloadIdentity requires Tenant Id, Client Id and Federated Token File.\n" +
+				"ManagedIdentity uses the identity of the host running Daytona. Set Client Id to use a user-assigned identity.\n" +
+				"AzureCLI uses the account logged in with \"az login\". Tenant Id is optional.\n" +
+				"Environment reads the AZURE_* environment variables supported by the Azure SDK.\n" +
+				"Default tries environment, workload identity, managed identity and Azure CLI credentials in order.",
+			Options: []string{
+				AuthMethodClientSecret,
+				AuthMethodClientCertificate,
+				AuthMethodWorkloadIdentity,
+				AuthMethodManagedIdentity,
+				AuthMethodAzureCLI,
+				AuthMethodEnvironment,
+				AuthMethodDefault,
+			},
 		},
 		"Tenant Id": models.TargetConfigProperty{
 			Type:        models.TargetConfigPropertyTypeString,
@@ -48,6 +216,25 @@ func GetTargetConfigManifest() *models.TargetConfigManifest {
 			Description: "Leave blank if you've set the AZURE_CLIENT_SECRET environment variable, or enter your Client Secret here.\n" +
 				"To find the this, look for \"password\" in the output after generating client credentials\nhttps://learn.microsoft.com/en-us/cli/azure/azure-cli-sp-tutorial-1?tabs=bash",
 		},
+		"Client Certificate": models.TargetConfigProperty{
+			Type:        models.TargetConfigPropertyTypeString,
+			InputMasked: true,
+			Description: "Leave blank if you've set the AZURE_CLIENT_CERTIFICATE_PATH environment variable, or enter the path to a PEM/PFX certificate or an inline PEM certificate here.\n" +
+				"The certificate must contain the private key. Only used with the ClientCertificate auth method.\n" +
+				"https://learn.microsoft.com/en-us/cli/azure/azure-cli-sp-tutorial-3?tabs=bash",
+		},
+		"Client Certificate Password": models.TargetConfigProperty{
+			Type:        models.TargetConfigPropertyTypeString,
+			InputMasked: true,
+			Description: "Leave blank if the certificate is not password protected or you've set the AZURE_CLIENT_CERTIFICATE_PASSWORD environment variable.\n" +
+				"Only used for PFX files with the ClientCertificate auth method.",
+		},
+		"Federated Token File": models.TargetConfigProperty{
+			Type: models.TargetConfigPropertyTypeString,
+			Description: "Leave blank if you've set the AZURE_FEDERATED_TOKEN_FILE environment variable, or enter the path to a federated OIDC token file here.\n" +
+				"Only used with the WorkloadIdentity auth method.\n" +
+				"https://learn.microsoft.com/en-us/entra/workload-id/workload-identity-federation",
+		},
 		"Subscription Id": models.TargetConfigProperty{
 			Type:        models.TargetConfigPropertyTypeString,
 			InputMasked: true,
@@ -65,15 +252,13 @@ func GetTargetConfigManifest() *models.TargetConfigManifest {
 			DefaultValue: "Canonical:ubuntu-24_04-lts:server:latest",
 			Description: "The identifier of the Azure virtual machine image to launch an instance. Default is Canonical:ubuntu-24_04-lts:server:latest.\n" +
 				"List of available images:\nhttps://learn.microsoft.com/en-us/azure/virtual-machines/linux/cli-ps-findimage",
-			Suggestions: imagesUrns,
+			Suggestions: suggestions.ImageURNs,
 		},
 		"VM Size": models.TargetConfigProperty{
 			Type:         models.TargetConfigPropertyTypeString,
 			DefaultValue: "Standard_B2s",
-			Description: "The size of the Azure machine. Default is Standard_A2_v2.\n" +
-				"List of available sizes:\nhttps://learn.microsoft.com/en-us/azure/virtual-machines/sizes/overview/" +
-				"List of available sizes per location can be retrieved using the command:\naz vm list-sizes --location <your-region> --output table",
-			Suggestions: vmSizes,
+			Description:  getVMSizeDescription(),
+			Suggestions:  SortVMSizes(suggestions.VMSizes),
 		},
 		"Disk Type": models.TargetConfigProperty{
 			Type:         models.TargetConfigPropertyTypeString,
@@ -81,13 +266,196 @@ func GetTargetConfigManifest() *models.TargetConfigManifest {
 			Description: "The type of the azure managed disk. Default is StandardSSD_LRS.\n" +
 				"List of available disk types:\nhttps://docs.microsoft.com/azure/virtual-machines/linux/disks-types" +
 				"List of available disk types per location can be retrieved using the command:\naz vm list-skus --location <your-region> --output table",
-			Suggestions: diskTypes,
+			Suggestions: suggestions.DiskTypes,
 		},
 		"Disk Size": models.TargetConfigProperty{
 			Type:         models.TargetConfigPropertyTypeInt,
 			DefaultValue: "30",
 			Description:  "The size of the instance volume, in GB. Default is 30 GB. It is recommended that the disk size should be more than 30 GB.",
 		},
+		"Priority": models.TargetConfigProperty{
+			Type:         models.TargetConfigPropertyTypeOption,
+			DefaultValue: PriorityRegular,
+			Description: "The priority of the virtual machine. Default is Regular.\n" +
+				"Spot runs the virtual machine on spare capacity at a discount, but Azure can evict it at any time.",
+			Options: []string{
+				PriorityRegular,
+				PrioritySpot,
+			},
+		},
+		"Eviction Policy": models.TargetConfigProperty{
+			Type:         models.TargetConfigPropertyTypeOption,
+			DefaultValue: EvictionPolicyDeallocate,
+			Description: "What happens to a Spot virtual machine when it is evicted. Default is Deallocate.\n" +
+				"Deallocate keeps the disk so the target can be started again. Delete deletes the virtual machine and its disk.",
+			Options: []string{
+				EvictionPolicyDeallocate,
+				EvictionPolicyDelete,
+			},
+		},
+		"Max Price": models.TargetConfigProperty{
+			Type:         models.TargetConfigPropertyTypeFloat,
+			DefaultValue: "-1",
+			Description: "The maximum hourly price of a Spot virtual machine in US dollars, e.g. 0.05.\n" +
+				"Default is -1, which caps the price at the pay-as-you-go price so the virtual machine is only evicted for capacity reasons.",
+		},
+		"Security Type": models.TargetConfigProperty{
+			Type:         models.TargetConfigPropertyTypeOption,
+			DefaultValue: SecurityTypeStandard,
+			Description: "The security type of the virtual machine. Default is Standard.\n" +
+				"TrustedLaunch and ConfidentialVM require a generation 2 image and a VM size that supports them.",
+			Options: []string{
+				SecurityTypeStandard,
+				SecurityTypeTrustedLaunch,
+				SecurityTypeConfidentialVM,
+			},
+		},
+		"Secure Boot": models.TargetConfigProperty{
+			Type:         models.TargetConfigPropertyTypeBoolean,
+			DefaultValue: "true",
+			Description:  "Enable Secure Boot with the TrustedLaunch and ConfidentialVM security types. Default is true.",
+		},
+		"vTPM": models.TargetConfigProperty{
+			Type:         models.TargetConfigPropertyTypeBoolean,
+			DefaultValue: "true",
+			Description: "Enable the virtual Trusted Platform Module with the TrustedLaunch and ConfidentialVM security types. Default is true.\n" +
+				"ConfidentialVM requires vTPM.",
+		},
+		"Network Mode": models.TargetConfigProperty{
+			Type:         models.TargetConfigPropertyTypeOption,
+			DefaultValue: NetworkModePerTarget,
+			Description: "How target networks are created. Default is PerTarget.\n" +
+				"PerTarget creates a separate virtual network for every target.\n" +
+				"Shared creates one Daytona virtual network per resource group and region, and gives every target its own subnet in it.",
+			Options: []string{
+				NetworkModePerTarget,
+				NetworkModeShared,
+			},
+		},
+		"Address Space": models.TargetConfigProperty{
+			Type:         models.TargetConfigPropertyTypeString,
+			DefaultValue: DefaultAddressSpace,
+			Description: "The address space of the virtual networks created by Daytona. Default is 10.10.0.0/16.\n" +
+				"Not used with a Subnet ID.\n" +
+				"It must not overlap the virtual networks in the resource group or the networks peered to them.",
+		},
+		"Subnet Prefix": models.TargetConfigProperty{
+			Type:         models.TargetConfigPropertyTypeString,
+			DefaultValue: DefaultSubnetPrefix,
+			Description: "The address prefix of the target subnet within the address space. Default is 10.10.10.0/24.\n" +
+				"Not used with a Subnet ID or in the Shared network mode, where subnets are allocated automatically.",
+		},
+		"DNS Servers": models.TargetConfigProperty{
+			Type: models.TargetConfigPropertyTypeString,
+			Description: "Comma-separated IP addresses of custom DNS servers for the virtual networks created by Daytona,\n" +
+				"e.g. 10.0.0.4,10.0.0.5. Azure-provided DNS is used if not set. Not used with a Subnet ID.",
+		},
+		"Private DNS Zone ID": models.TargetConfigProperty{
+			Type: models.TargetConfigPropertyTypeString,
+			Description: "The ARM resource ID of an Azure Private DNS zone to register targets in as <target-name>.<zone>, e.g.\n" +
+				"/subscriptions/<subscription-id>/resourceGroups/<resource-group>/providers/Microsoft.Network/privateDnsZones/<zone>\n" +
+				"The record is deleted together with the target.",
+		},
+		"HTTP Proxy": models.TargetConfigProperty{
+			Type: models.TargetConfigPropertyTypeString,
+			Description: "The proxy for HTTP traffic from the target, e.g. http://proxy.example.com:3128.\n" +
+				"Used by the bootstrap script, the Docker daemon and the Daytona agent.",
+		},
+		"HTTPS Proxy": models.TargetConfigProperty{
+			Type: models.TargetConfigPropertyTypeString,
+			Description: "The proxy for HTTPS traffic from the target, e.g. http://proxy.example.com:3128.\n" +
+				"Used by the bootstrap script, the Docker daemon and the Daytona agent.",
+		},
+		"No Proxy": models.TargetConfigProperty{
+			Type: models.TargetConfigPropertyTypeString,
+			Description: "Comma-separated hosts and domains that bypass the proxy, e.g. .internal,10.0.0.0/8.\n" +
+				"localhost and the Azure instance metadata and platform endpoints are always added.",
+		},
+		"CA Bundle": models.TargetConfigProperty{
+			Type: models.TargetConfigPropertyTypeFilePath,
+			Description: "Path to a PEM file with additional CA certificates to trust on the target, e.g. for a TLS-inspecting proxy.\n" +
+				"The PEM content can also be set directly.",
+		},
+		"Subnet ID": models.TargetConfigProperty{
+			Type: models.TargetConfigPropertyTypeString,
+			Description: "The ARM resource ID of an existing subnet to attach targets to, e.g.\n" +
+				"/subscriptions/<subscription-id>/resourceGroups/<resource-group>/providers/Microsoft.Network/virtualNetworks/<vnet>/subnets/<subnet>\n" +
+				"The subnet may be in another resource group or subscription of the same tenant, but its virtual network must be in\n" +
+				"the target region. The virtual network and subnet are read with the credentials of the target.\n" +
+				"When set, no virtual network or subnet is created or deleted by Daytona.",
+		},
+		"Inbound Rules": models.TargetConfigProperty{
+			Type: models.TargetConfigPropertyTypeString,
+			Description: "Additional inbound traffic to allow through the network security group of the target.\n" +
+				"All other inbound traffic is denied. Comma-separated <protocol>:<ports>:<source> rules, e.g.\n" +
+				"tcp:22:10.0.0.0/8,tcp:8000-8100:VirtualNetwork",
+		},
+		"SSH Public Key": models.TargetConfigProperty{
+			Type: models.TargetConfigPropertyTypeString,
+			Description: "The OpenSSH public key authorized for the daytona admin user, e.g. ssh-ed25519 AAAA...\n" +
+				"Leave blank to generate a keypair for every target. Password authentication is always disabled.",
+		},
+		"SSH Key Passphrase": models.TargetConfigProperty{
+			Type:        models.TargetConfigPropertyTypeString,
+			InputMasked: true,
+			Description: "Leave blank if you've set the AZURE_SSH_KEY_PASSPHRASE environment variable, or enter the passphrase\n" +
+				"that encrypts the generated private keys stored under the provider base path.\n" +
+				"Required unless SSH Public Key is set.",
+		},
+		"Egress Policy": models.TargetConfigProperty{
+			Type:         models.TargetConfigPropertyTypeOption,
+			DefaultValue: EgressPolicyAllowAll,
+			Description: "The outbound traffic allowed from the target. Default is AllowAll.\n" +
+				"AllowList denies all outbound traffic except the Egress Allow List. The Daytona server must stay reachable.",
+			Options: []string{
+				EgressPolicyAllowAll,
+				EgressPolicyAllowList,
+			},
+		},
+		"Egress Allow List": models.TargetConfigProperty{
+			Type: models.TargetConfigPropertyTypeString,
+			Description: "Outbound traffic to allow with the AllowList egress policy. Comma-separated <protocol>:<ports>:<destination>\n" +
+				"rules, where the destination is an IP address, a CIDR block or a service tag, e.g.\n" +
+				"tcp:443:AzureCloud,tcp:443:140.82.112.0/20,tcp:80-443:Internet",
+		},
+		"Public IP": models.TargetConfigProperty{
+			Type:         models.TargetConfigPropertyTypeOption,
+			DefaultValue: PublicIPNone,
+			Description: "Attach a static Standard SKU public IP address to the target. Default is None.\n" +
+				"Inbound traffic to the public IP address is still limited by the Inbound Rules.",
+			Options: []string{
+				PublicIPNone,
+				PublicIPStatic,
+			},
+		},
+		"Public IP DNS Label": models.TargetConfigProperty{
+			Type: models.TargetConfigPropertyTypeString,
+			Description: "The DNS label of the public IP address. The target is then reachable at\n" +
+				"<label>.<region>.cloudapp.azure.com. The label must be unique in the region.",
+		},
+		"NAT Gateway": models.TargetConfigProperty{
+			Type:         models.TargetConfigPropertyTypeOption,
+			DefaultValue: NATGatewayNone,
+			Description: "Create a NAT gateway with a static egress IP address for the target subnet. Default is None.\n" +
+				"Azure is retiring default outbound access for new virtual networks, so targets without a NAT gateway,\n" +
+				"public IP address or existing subnet with outbound access may not reach the internet.",
+			Options: []string{
+				NATGatewayNone,
+				NATGatewayCreate,
+			},
+		},
+		"NAT Gateway ID": models.TargetConfigProperty{
+			Type: models.TargetConfigPropertyTypeString,
+			Description: "The ARM resource ID of an existing NAT gateway to attach to the target subnet, e.g.\n" +
+				"/subscriptions/<subscription-id>/resourceGroups/<resource-group>/providers/Microsoft.Network/natGateways/<name>\n" +
+				"The NAT gateway must be in the target region and is never deleted by Daytona.",
+		},
+		"Accelerated Networking": models.TargetConfigProperty{
+			Type:         models.TargetConfigPropertyTypeBoolean,
+			DefaultValue: "false",
+			Description: "Enable accelerated networking on the network interface of the target. Default is false.\n" +
+				"It is turned off with a warning if the VM size does not support it.",
+		},
 	}
 }
 
@@ -120,6 +488,27 @@ func ParseTargetOptions(optionsJson string) (*TargetOptions, error) {
 		}
 	}
 
+	if targetOptions.ClientCertificate == "" {
+		clientCertificate, ok := os.LookupEnv("AZURE_CLIENT_CERTIFICATE_PATH")
+		if ok {
+			targetOptions.ClientCertificate = clientCertificate
+		}
+	}
+
+	if targetOptions.ClientCertificatePassword == "" {
+		clientCertificatePassword, ok := os.LookupEnv("AZURE_CLIENT_CERTIFICATE_PASSWORD")
+		if ok {
+			targetOptions.ClientCertificatePassword = clientCertificatePassword
+		}
+	}
+
+	if targetOptions.FederatedTokenFile == "" {
+		federatedTokenFile, ok := os.LookupEnv("AZURE_FEDERATED_TOKEN_FILE")
+		if ok {
+			targetOptions.FederatedTokenFile = federatedTokenFile
+		}
+	}
+
 	if targetOptions.SubscriptionId == "" {
 		subscriptionId, ok := os.LookupEnv("AZURE_SUBSCRIPTION_ID")
 		if ok {
@@ -127,18 +516,489 @@ func ParseTargetOptions(optionsJson string) (*TargetOptions, error) {
 		}
 	}
 
-	if targetOptions.TenantId == "" {
-		return nil, fmt.Errorf("tenant id not set in env/target options")
+	if targetOptions.SSHKeyPassphrase == "" {
+		sshKeyPassphrase, ok := os.LookupEnv("AZURE_SSH_KEY_PASSPHRASE")
+		if ok {
+			targetOptions.SSHKeyPassphrase = sshKeyPassphrase
+		}
 	}
-	if targetOptions.ClientId == "" {
-		return nil, fmt.Errorf("client id not set in env/target options")
+
+	err = validateAuthOptions(&targetOptions)
+	if err != nil {
+		return nil, err
 	}
-	if targetOptions.ClientSecret == "" {
-		return nil, fmt.Errorf("client secret not set in env/target options")
+
+	err = validateCloudOptions(&targetOptions)
+	if err != nil {
+		return nil, err
 	}
+
 	if targetOptions.SubscriptionId == "" {
 		return nil, fmt.Errorf("subscription id not set in env/target options")
 	}
 
+	err = validateNetworkOptions(&targetOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validatePublicIPOptions(&targetOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateAvailabilityZoneOptions(&targetOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateNATGatewayOptions(&targetOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateDNSOptions(&targetOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateProxyOptions(&targetOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateSpotOptions(&targetOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateSecurityOptions(&targetOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateEgressOptions(&targetOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateSSHOptions(&targetOptions)
+	if err != nil {
+		return nil, err
+	}
+
 	return &targetOptions, nil
 }
+
+// validateAuthOptions checks that the fields required by the selected auth method are set.
+func validateAuthOptions(targetOptions *TargetOptions) error {
+	switch targetOptions.AuthMethod {
+	case "", AuthMethodClientSecret:
+		if targetOptions.TenantId == "" {
+			return fmt.Errorf("tenant id not set in env/target options")
+		}
+		if targetOptions.ClientId == "" {
+			return fmt.Errorf("client id not set in env/target options")
+		}
+		if targetOptions.ClientSecret == "" {
+			return fmt.Errorf("client secret not set in env/target options")
+		}
+	case AuthMethodClientCertificate:
+		if targetOptions.TenantId == "" {
+			return fmt.Errorf("tenant id not set in env/target options")
+		}
+		if targetOptions.ClientId == "" {
+			return fmt.Errorf("client id not set in env/target options")
+		}
+		if targetOptions.ClientCertificate == "" {
+			return fmt.Errorf("client certificate not set in env/target options")
+		}
+	case AuthMethodWorkloadIdentity:
+		if targetOptions.TenantId == "" {
+			return fmt.Errorf("tenant id not set in env/target options")
+		}
+		if targetOptions.ClientId == "" {
+			return fmt.Errorf("client id not set in env/target options")
+		}
+		if targetOptions.FederatedTokenFile == "" {
+			return fmt.Errorf("federated token file not set in env/target options")
+		}
+	case AuthMethodManagedIdentity, AuthMethodAzureCLI, AuthMethodEnvironment, AuthMethodDefault:
+	default:
+		return fmt.Errorf("unsupported auth method: %s", targetOptions.AuthMethod)
+	}
+
+	return nil
+}
+
+// validateCloudOptions checks the selected cloud and, for custom clouds, the endpoint URLs.
+func validateCloudOptions(targetOptions *TargetOptions) error {
+	switch targetOptions.Cloud {
+	case "", CloudAzurePublic, CloudAzureGovernment, CloudAzureChina:
+	case CloudCustom:
+		if targetOptions.ARMEndpoint == "" {
+			return fmt.Errorf("arm endpoint not set for custom cloud")
+		}
+		if targetOptions.AuthorityHost == "" {
+			return fmt.Errorf("authority host not set for custom cloud")
+		}
+
+		endpoints := []struct{ name, value string }{
+			{"arm endpoint", targetOptions.ARMEndpoint},
+			{"authority host", targetOptions.AuthorityHost},
+			{"arm audience", targetOptions.ARMAudience},
+		}
+		for _, endpoint := range endpoints {
+			if endpoint.value == "" {
+				continue
+			}
+			u, err := url.Parse(endpoint.value)
+			if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+				return fmt.Errorf("invalid %s: %s", endpoint.name, endpoint.value)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported cloud: %s", targetOptions.Cloud)
+	}
+
+	return nil
+}
+
+// validateNetworkOptions checks the network mode, the inbound rules, the address prefixes
+// and the subnet resource ID.
+func validateNetworkOptions(targetOptions *TargetOptions) error {
+	switch targetOptions.NetworkMode {
+	case "", NetworkModePerTarget, NetworkModeShared:
+	default:
+		return fmt.Errorf("unsupported network mode: %s", targetOptions.NetworkMode)
+	}
+
+	_, err := ParseSecurityRules(targetOptions.InboundRules)
+	if err != nil {
+		return fmt.Errorf("invalid inbound rules: %w", err)
+	}
+
+	if targetOptions.SubnetId == "" {
+		// Subnets in the shared network mode are allocated automatically
+		if targetOptions.NetworkMode == NetworkModeShared {
+			_, err = parseCIDR(targetOptions.GetAddressSpace())
+			if err != nil {
+				return fmt.Errorf("invalid address space: %w", err)
+			}
+			return nil
+		}
+
+		return validateAddressPrefixes(targetOptions.GetAddressSpace(), targetOptions.GetSubnetPrefix())
+	}
+
+	if targetOptions.NetworkMode == NetworkModeShared {
+		return fmt.Errorf("subnet id cannot be used with the shared network mode")
+	}
+
+	subnetId, err := arm.ParseResourceID(targetOptions.SubnetId)
+	if err != nil || !strings.EqualFold(subnetId.ResourceType.String(), "Microsoft.Network/virtualNetworks/subnets") {
+		return fmt.Errorf("invalid subnet id: %s", targetOptions.SubnetId)
+	}
+
+	return nil
+}
+
+// validatePublicIPOptions checks the public IP allocation method and DNS label. Basic SKU
+// and dynamic public IP addresses are retired, so only static addresses are supported.
+func validatePublicIPOptions(targetOptions *TargetOptions) error {
+	switch targetOptions.PublicIP {
+	case "", PublicIPNone:
+		if targetOptions.PublicIPDNSLabel != "" {
+			return fmt.Errorf("public ip dns label requires a public ip")
+		}
+		return nil
+	case PublicIPStatic:
+	default:
+		return fmt.Errorf("unsupported public ip allocation method: %s", targetOptions.PublicIP)
+	}
+
+	if targetOptions.PublicIPDNSLabel != "" && !dnsLabelPattern.MatchString(targetOptions.PublicIPDNSLabel) {
+		return fmt.Errorf("invalid public ip dns label: %s", targetOptions.PublicIPDNSLabel)
+	}
+
+	return nil
+}
+
+// validateAvailabilityZoneOptions checks the availability zone.
+func validateAvailabilityZoneOptions(targetOptions *TargetOptions) error {
+	if targetOptions.AvailabilityZone == "" {
+		return nil
+	}
+
+	if targetOptions.AvailabilityZone != AvailabilityZoneAny && !availabilityZonePattern.MatchString(targetOptions.AvailabilityZone) {
+		return fmt.Errorf("invalid availability zone: %s", targetOptions.AvailabilityZone)
+	}
+
+	return nil
+}
+
+// validateNATGatewayOptions checks the NAT gateway options. A NAT gateway can only be
+// attached to subnets created by Daytona.
+func validateNATGatewayOptions(targetOptions *TargetOptions) error {
+	switch targetOptions.NATGateway {
+	case "", NATGatewayNone:
+	case NATGatewayCreate:
+		if targetOptions.NATGatewayId != "" {
+			return fmt.Errorf("nat gateway id cannot be used when creating a nat gateway")
+		}
+	default:
+		return fmt.Errorf("unsupported nat gateway option: %s", targetOptions.NATGateway)
+	}
+
+	if targetOptions.NATGatewayId != "" {
+		natGatewayId, err := arm.ParseResourceID(targetOptions.NATGatewayId)
+		if err != nil || !strings.EqualFold(natGatewayId.ResourceType.String(), "Microsoft.Network/natGateways") {
+			return fmt.Errorf("invalid nat gateway id: %s", targetOptions.NATGatewayId)
+		}
+	}
+
+	if targetOptions.HasNATGateway() && targetOptions.SubnetId != "" {
+		return fmt.Errorf("a nat gateway cannot be attached to an existing subnet")
+	}
+
+	return nil
+}
+
+// validateDNSOptions checks the custom DNS servers and the Private DNS zone resource ID.
+func validateDNSOptions(targetOptions *TargetOptions) error {
+	for _, server := range targetOptions.GetDNSServers() {
+		address, err := netip.ParseAddr(server)
+		if err != nil || !address.Is4() {
+			return fmt.Errorf("invalid dns server: %s", server)
+		}
+	}
+
+	if targetOptions.PrivateDNSZoneId != "" {
+		zoneId, err := arm.ParseResourceID(targetOptions.PrivateDNSZoneId)
+		if err != nil || !strings.EqualFold(zoneId.ResourceType.String(), "Microsoft.Network/privateDnsZones") {
+			return fmt.Errorf("invalid private dns zone id: %s", targetOptions.PrivateDNSZoneId)
+		}
+	}
+
+	return nil
+}
+
+// validateProxyOptions checks that the proxies are http, https or socks5 URLs.
+func validateProxyOptions(targetOptions *TargetOptions) error {
+	proxies := []struct{ name, value string }{
+		{"http proxy", targetOptions.HTTPProxy},
+		{"https proxy", targetOptions.HTTPSProxy},
+	}
+	for _, proxy := range proxies {
+		if proxy.value == "" {
+			continue
+		}
+		u, err := url.Parse(proxy.value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5") || u.Host == "" || containsSpaceOrControl(proxy.value) {
+			return fmt.Errorf("invalid %s: %q", proxy.name, proxy.value)
+		}
+	}
+
+	for _, host := range strings.Split(targetOptions.NoProxy, ",") {
+		if containsSpaceOrControl(strings.TrimSpace(host)) {
+			return fmt.Errorf("invalid no proxy host: %q", host)
+		}
+	}
+
+	return nil
+}
+
+// containsSpaceOrControl reports whether the value contains whitespace or control
+// characters, which are not valid in proxy settings and could break the generated
+// bootstrap script and systemd units.
+func containsSpaceOrControl(value string) bool {
+	return strings.IndexFunc(value, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r)
+	}) >= 0
+}
+
+// validateSecurityOptions checks the security type. Secure Boot and vTPM are only used
+// by the TrustedLaunch and ConfidentialVM security types.
+func validateSecurityOptions(targetOptions *TargetOptions) error {
+	switch targetOptions.SecurityType {
+	case "", SecurityTypeStandard, SecurityTypeTrustedLaunch:
+	case SecurityTypeConfidentialVM:
+		if !targetOptions.IsVTPMEnabled() {
+			return fmt.Errorf("the %s security type requires vTPM", SecurityTypeConfidentialVM)
+		}
+	default:
+		return fmt.Errorf("unsupported security type: %s", targetOptions.SecurityType)
+	}
+
+	return nil
+}
+
+// validateSpotOptions checks the priority, eviction policy and max price. The eviction
+// policy and max price are only used by Spot virtual machines.
+func validateSpotOptions(targetOptions *TargetOptions) error {
+	switch targetOptions.Priority {
+	case "", PriorityRegular, PrioritySpot:
+	default:
+		return fmt.Errorf("unsupported priority: %s", targetOptions.Priority)
+	}
+
+	switch targetOptions.EvictionPolicy {
+	case "", EvictionPolicyDeallocate, EvictionPolicyDelete:
+	default:
+		return fmt.Errorf("unsupported eviction policy: %s", targetOptions.EvictionPolicy)
+	}
+
+	if targetOptions.MaxPrice < 0 && targetOptions.MaxPrice != NoMaxPrice {
+		return fmt.Errorf("invalid max price %v: must be greater than 0 or %d", targetOptions.MaxPrice, NoMaxPrice)
+	}
+
+	return nil
+}
+
+// validateEgressOptions checks the egress policy and the egress allow list.
+func validateEgressOptions(targetOptions *TargetOptions) error {
+	switch targetOptions.EgressPolicy {
+	case "", EgressPolicyAllowAll, EgressPolicyAllowList:
+	default:
+		return fmt.Errorf("unsupported egress policy: %s", targetOptions.EgressPolicy)
+	}
+
+	_, err := ParseSecurityRules(targetOptions.EgressAllowList)
+	if err != nil {
+		return fmt.Errorf("invalid egress allow list: %w", err)
+	}
+
+	return nil
+}
+
+// validateSSHOptions checks that the SSH public key is an RSA or Ed25519 key, the key
+// types supported by Azure virtual machines.
+func validateSSHOptions(targetOptions *TargetOptions) error {
+	if targetOptions.SSHPublicKey == "" {
+		return nil
+	}
+
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(targetOptions.SSHPublicKey))
+	if err != nil {
+		return fmt.Errorf("invalid SSH public key: %w", err)
+	}
+
+	switch publicKey.Type() {
+	case ssh.KeyAlgoRSA, ssh.KeyAlgoED25519:
+	default:
+		return fmt.Errorf("unsupported SSH public key type %s, use an RSA or Ed25519 key", publicKey.Type())
+	}
+
+	return nil
+}
+
+// VMSizeList returns the VM sizes of the comma-separated VM size option, in the order
+// they are tried.
+func (o *TargetOptions) VMSizeList() []string {
+	vmSizes := []string{}
+	for _, vmSize := range strings.Split(o.VMSize, ",") {
+		vmSize = strings.TrimSpace(vmSize)
+		if vmSize != "" {
+			vmSizes = append(vmSizes, vmSize)
+		}
+	}
+	return vmSizes
+}
+
+// GetSecurityType returns the security type of the virtual machine.
+func (o *TargetOptions) GetSecurityType() string {
+	if o.SecurityType == "" {
+		return SecurityTypeStandard
+	}
+	return o.SecurityType
+}
+
+// IsSecureBootEnabled reports whether Secure Boot is enabled. It is enabled unless it is
+// explicitly turned off.
+func (o *TargetOptions) IsSecureBootEnabled() bool {
+	return o.SecureBoot == nil || *o.SecureBoot
+}
+
+// IsVTPMEnabled reports whether the vTPM is enabled. It is enabled unless it is
+// explicitly turned off.
+func (o *TargetOptions) IsVTPMEnabled() bool {
+	return o.VTPM == nil || *o.VTPM
+}
+
+// IsSpot reports whether the target runs on a Spot virtual machine.
+func (o *TargetOptions) IsSpot() bool {
+	return o.Priority == PrioritySpot
+}
+
+// GetEvictionPolicy returns the eviction policy of Spot virtual machines.
+func (o *TargetOptions) GetEvictionPolicy() string {
+	if o.EvictionPolicy == "" {
+		return EvictionPolicyDeallocate
+	}
+	return o.EvictionPolicy
+}
+
+// GetMaxPrice returns the maximum price of Spot virtual machines. An unset max price
+// means no cap below the pay-as-you-go price.
+func (o *TargetOptions) GetMaxPrice() float64 {
+	if o.MaxPrice == 0 {
+		return NoMaxPrice
+	}
+	return o.MaxPrice
+}
+
+// HasPublicIP reports whether the target gets a public IP address.
+func (o *TargetOptions) HasPublicIP() bool {
+	return o.PublicIP == PublicIPStatic
+}
+
+// HasNATGateway reports whether a created or existing NAT gateway is attached to the target subnet.
+func (o *TargetOptions) HasNATGateway() bool {
+	return o.NATGateway == NATGatewayCreate || o.NATGatewayId != ""
+}
+
+// GetAddressSpace returns the address space of the virtual network, or the default.
+func (o *TargetOptions) GetAddressSpace() string {
+	if o.AddressSpace == "" {
+		return DefaultAddressSpace
+	}
+	return o.AddressSpace
+}
+
+// GetSubnetPrefix returns the address prefix of the target subnet, or the default.
+func (o *TargetOptions) GetSubnetPrefix() string {
+	if o.SubnetPrefix == "" {
+		return DefaultSubnetPrefix
+	}
+	return o.SubnetPrefix
+}
+
+// GetDNSServers returns the custom DNS servers.
+func (o *TargetOptions) GetDNSServers() []string {
+	servers := []string{}
+	for _, server := range strings.Split(o.DNSServers, ",") {
+		server = strings.TrimSpace(server)
+		if server != "" {
+			servers = append(servers, server)
+		}
+	}
+	return servers
+}
+
+// HasProxy reports whether the target uses a proxy.
+func (o *TargetOptions) HasProxy() bool {
+	return o.HTTPProxy != "" || o.HTTPSProxy != ""
+}
+
+// GetNoProxy returns the hosts that bypass the proxy, including localhost and the Azure
+// instance metadata and platform endpoints.
+func (o *TargetOptions) GetNoProxy() string {
+	noProxy := []string{"localhost", "127.0.0.1", "169.254.169.254", "168.63.129.16"}
+	for _, host := range strings.Split(o.NoProxy, ",") {
+		host = strings.TrimSpace(host)
+		if host != "" {
+			noProxy = append(noProxy, host)
+		}
+	}
+	return strings.Join(noProxy, ",")
+}
@@ -11,8 +11,14 @@ func TestGetTargetConfigManifest(t *testing.T) {
 		t.Fatalf("Expected target config manifest but got nil")
 	}
 
-	fields := [10]string{"Region", "Tenant Id", "Client Id", "Client Secret",
-		"Subscription Id", "Image URN", "VM Size", "Disk Type", "Disk Size", "Resource Group",
+	fields := [45]string{"Region", "Cloud", "ARM Endpoint", "Authority Host", "ARM Audience", "Auth Method", "Tenant Id", "Client Id", "Client Secret",
+		"Client Certificate", "Client Certificate Password", "Federated Token File", "Subscription Id", "Image URN", "VM Size", "Disk Type", "Disk Size", "Resource Group",
+		"Network Mode", "Subnet ID", "Inbound Rules", "Public IP", "Public IP DNS Label",
+		"NAT Gateway", "NAT Gateway ID", "Accelerated Networking", "Address Space", "Subnet Prefix",
+		"DNS Servers", "Private DNS Zone ID", "HTTP Proxy", "HTTPS Proxy", "No Proxy", "CA Bundle",
+		"Egress Policy", "Egress Allow List", "Priority", "Eviction Policy", "Max Price",
+		"SSH Public Key", "SSH Key Passphrase", "Security Type", "Secure Boot", "vTPM",
+		"Availability Zone",
 	}
 	for _, field := range fields {
 		if _, ok := (*targetConfigManifest)[field]; !ok {
@@ -127,6 +133,575 @@ func TestParseTargetOptions(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Managed identity without client secret",
+			optionsJson: `{
+				"Auth Method": "ManagedIdentity",
+				"Subscription Id": "subscription-id-123"
+			}`,
+			want: &TargetOptions{
+				AuthMethod:     AuthMethodManagedIdentity,
+				SubscriptionId: "subscription-id-123",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Azure CLI with optional tenant id",
+			optionsJson: `{
+				"Auth Method": "AzureCLI",
+				"Tenant Id": "tenant-id-123",
+				"Subscription Id": "subscription-id-123"
+			}`,
+			want: &TargetOptions{
+				AuthMethod:     AuthMethodAzureCLI,
+				TenantId:       "tenant-id-123",
+				SubscriptionId: "subscription-id-123",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Client certificate using env vars",
+			optionsJson: `{
+				"Auth Method": "ClientCertificate",
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Subscription Id": "subscription-id-123"
+			}`,
+			envVars: map[string]string{
+				"AZURE_CLIENT_CERTIFICATE_PATH":     "/etc/azure/cert.pem",
+				"AZURE_CLIENT_CERTIFICATE_PASSWORD": "cert-password-123",
+			},
+			want: &TargetOptions{
+				AuthMethod:                AuthMethodClientCertificate,
+				TenantId:                  "tenant-id-123",
+				ClientId:                  "client-id-123",
+				ClientCertificate:         "/etc/azure/cert.pem",
+				ClientCertificatePassword: "cert-password-123",
+				SubscriptionId:            "subscription-id-123",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Client certificate missing certificate",
+			optionsJson: `{
+				"Auth Method": "ClientCertificate",
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Subscription Id": "subscription-id-123"
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "Workload identity using env vars",
+			optionsJson: `{
+				"Auth Method": "WorkloadIdentity",
+				"Subscription Id": "subscription-id-123"
+			}`,
+			envVars: map[string]string{
+				"AZURE_TENANT_ID":            "tenant-id-123",
+				"AZURE_CLIENT_ID":            "client-id-123",
+				"AZURE_FEDERATED_TOKEN_FILE": "/var/run/secrets/azure/tokens/azure-identity-token",
+			},
+			want: &TargetOptions{
+				AuthMethod:         AuthMethodWorkloadIdentity,
+				TenantId:           "tenant-id-123",
+				ClientId:           "client-id-123",
+				FederatedTokenFile: "/var/run/secrets/azure/tokens/azure-identity-token",
+				SubscriptionId:     "subscription-id-123",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Custom cloud with endpoints",
+			optionsJson: `{
+				"Cloud": "Custom",
+				"ARM Endpoint": "https://management.local.azurestack.external/",
+				"Authority Host": "https://login.local.azurestack.external/",
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123"
+			}`,
+			want: &TargetOptions{
+				Cloud:          CloudCustom,
+				ARMEndpoint:    "https://management.local.azurestack.external/",
+				AuthorityHost:  "https://login.local.azurestack.external/",
+				TenantId:       "tenant-id-123",
+				ClientId:       "client-id-123",
+				ClientSecret:   "client-secret-123",
+				SubscriptionId: "subscription-id-123",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Custom cloud missing arm endpoint",
+			optionsJson: `{
+				"Cloud": "Custom",
+				"Authority Host": "https://login.local.azurestack.external/",
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123"
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "Shared network mode",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"Network Mode": "Shared"
+			}`,
+			want: &TargetOptions{
+				TenantId:       "tenant-id-123",
+				ClientId:       "client-id-123",
+				ClientSecret:   "client-secret-123",
+				SubscriptionId: "subscription-id-123",
+				NetworkMode:    NetworkModeShared,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Unsupported network mode",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"Network Mode": "Peered"
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "Existing subnet",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"Subnet ID": "/subscriptions/hub-subscription/resourceGroups/network/providers/Microsoft.Network/virtualNetworks/spoke/subnets/daytona"
+			}`,
+			want: &TargetOptions{
+				TenantId:       "tenant-id-123",
+				ClientId:       "client-id-123",
+				ClientSecret:   "client-secret-123",
+				SubscriptionId: "subscription-id-123",
+				SubnetId:       "/subscriptions/hub-subscription/resourceGroups/network/providers/Microsoft.Network/virtualNetworks/spoke/subnets/daytona",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Subnet id of another resource type",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"Subnet ID": "/subscriptions/hub-subscription/resourceGroups/network/providers/Microsoft.Network/virtualNetworks/spoke"
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "Invalid inbound rules",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"Inbound Rules": "tcp:ssh:*"
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "Static public ip with dns label",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"Public IP": "Static",
+				"Public IP DNS Label": "daytona-dev-1"
+			}`,
+			want: &TargetOptions{
+				TenantId:         "tenant-id-123",
+				ClientId:         "client-id-123",
+				ClientSecret:     "client-secret-123",
+				SubscriptionId:   "subscription-id-123",
+				PublicIP:         PublicIPStatic,
+				PublicIPDNSLabel: "daytona-dev-1",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Dynamic public ip",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"Public IP": "Dynamic"
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "Dns label without public ip",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"Public IP DNS Label": "daytona-dev-1"
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "Existing nat gateway",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"NAT Gateway ID": "/subscriptions/subscription-id-123/resourceGroups/network/providers/Microsoft.Network/natGateways/egress"
+			}`,
+			want: &TargetOptions{
+				TenantId:       "tenant-id-123",
+				ClientId:       "client-id-123",
+				ClientSecret:   "client-secret-123",
+				SubscriptionId: "subscription-id-123",
+				NATGatewayId:   "/subscriptions/subscription-id-123/resourceGroups/network/providers/Microsoft.Network/natGateways/egress",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Nat gateway with existing subnet",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"NAT Gateway": "Create",
+				"Subnet ID": "/subscriptions/hub-subscription/resourceGroups/network/providers/Microsoft.Network/virtualNetworks/spoke/subnets/daytona"
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "Custom address space and subnet prefix",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"Address Space": "172.20.0.0/16",
+				"Subnet Prefix": "172.20.4.0/22"
+			}`,
+			want: &TargetOptions{
+				TenantId:       "tenant-id-123",
+				ClientId:       "client-id-123",
+				ClientSecret:   "client-secret-123",
+				SubscriptionId: "subscription-id-123",
+				AddressSpace:   "172.20.0.0/16",
+				SubnetPrefix:   "172.20.4.0/22",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Subnet prefix outside the address space",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"Address Space": "172.20.0.0/16"
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "Address space that is not a network address",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"Address Space": "10.10.1.0/16",
+				"Subnet Prefix": "10.10.1.0/24"
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "Custom dns servers and private dns zone",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"DNS Servers": "10.0.0.4, 10.0.0.5",
+				"Private DNS Zone ID": "/subscriptions/subscription-id-123/resourceGroups/dns/providers/Microsoft.Network/privateDnsZones/dev.internal"
+			}`,
+			want: &TargetOptions{
+				TenantId:         "tenant-id-123",
+				ClientId:         "client-id-123",
+				ClientSecret:     "client-secret-123",
+				SubscriptionId:   "subscription-id-123",
+				DNSServers:       "10.0.0.4, 10.0.0.5",
+				PrivateDNSZoneId: "/subscriptions/subscription-id-123/resourceGroups/dns/providers/Microsoft.Network/privateDnsZones/dev.internal",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid dns server",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"DNS Servers": "dns.example.com"
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "Proxy options",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"HTTP Proxy": "http://proxy.example.com:3128",
+				"HTTPS Proxy": "http://proxy.example.com:3128",
+				"No Proxy": ".internal"
+			}`,
+			want: &TargetOptions{
+				TenantId:       "tenant-id-123",
+				ClientId:       "client-id-123",
+				ClientSecret:   "client-secret-123",
+				SubscriptionId: "subscription-id-123",
+				HTTPProxy:      "http://proxy.example.com:3128",
+				HTTPSProxy:     "http://proxy.example.com:3128",
+				NoProxy:        ".internal",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Proxy without scheme",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"HTTPS Proxy": "proxy.example.com:3128"
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "No proxy with newline",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"HTTPS Proxy": "http://proxy.example.com:3128",
+				"No Proxy": ".internal\nEOF"
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "No proxy with whitespace",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"HTTPS Proxy": "http://proxy.example.com:3128",
+				"No Proxy": ".internal, git.example.com registry.example.com"
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "Egress allow list",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"Egress Policy": "AllowList",
+				"Egress Allow List": "tcp:443:AzureCloud,tcp:443:140.82.112.0/20"
+			}`,
+			want: &TargetOptions{
+				TenantId:        "tenant-id-123",
+				ClientId:        "client-id-123",
+				ClientSecret:    "client-secret-123",
+				SubscriptionId:  "subscription-id-123",
+				EgressPolicy:    EgressPolicyAllowList,
+				EgressAllowList: "tcp:443:AzureCloud,tcp:443:140.82.112.0/20",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid egress allow list",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"Egress Policy": "AllowList",
+				"Egress Allow List": "https://github.com"
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "Spot virtual machine",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"Priority": "Spot",
+				"Eviction Policy": "Delete",
+				"Max Price": 0.05
+			}`,
+			want: &TargetOptions{
+				TenantId:       "tenant-id-123",
+				ClientId:       "client-id-123",
+				ClientSecret:   "client-secret-123",
+				SubscriptionId: "subscription-id-123",
+				Priority:       PrioritySpot,
+				EvictionPolicy: EvictionPolicyDelete,
+				MaxPrice:       0.05,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid max price",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"Priority": "Spot",
+				"Max Price": -0.5
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "SSH public key",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"SSH Public Key": "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIDlqOhXhkK0BC5hA6SXG2d0Gm5Z5nGZbqJoWvPqz7jzF admin@example.com"
+			}`,
+			want: &TargetOptions{
+				TenantId:       "tenant-id-123",
+				ClientId:       "client-id-123",
+				ClientSecret:   "client-secret-123",
+				SubscriptionId: "subscription-id-123",
+				SSHPublicKey:   "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIDlqOhXhkK0BC5hA6SXG2d0Gm5Z5nGZbqJoWvPqz7jzF admin@example.com",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid SSH public key",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"SSH Public Key": "ssh-ed25519 not-a-key"
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "Trusted launch",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"Security Type": "TrustedLaunch",
+				"Secure Boot": true,
+				"vTPM": true
+			}`,
+			want: &TargetOptions{
+				TenantId:       "tenant-id-123",
+				ClientId:       "client-id-123",
+				ClientSecret:   "client-secret-123",
+				SubscriptionId: "subscription-id-123",
+				SecurityType:   SecurityTypeTrustedLaunch,
+				SecureBoot:     boolPtr(true),
+				VTPM:           boolPtr(true),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Confidential VM with default vTPM",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"Security Type": "ConfidentialVM"
+			}`,
+			want: &TargetOptions{
+				TenantId:       "tenant-id-123",
+				ClientId:       "client-id-123",
+				ClientSecret:   "client-secret-123",
+				SubscriptionId: "subscription-id-123",
+				SecurityType:   SecurityTypeConfidentialVM,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Confidential VM without vTPM",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"Security Type": "ConfidentialVM",
+				"Secure Boot": true,
+				"vTPM": false
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "Any availability zone",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"Availability Zone": "any"
+			}`,
+			want: &TargetOptions{
+				TenantId:         "tenant-id-123",
+				ClientId:         "client-id-123",
+				ClientSecret:     "client-secret-123",
+				SubscriptionId:   "subscription-id-123",
+				AvailabilityZone: AvailabilityZoneAny,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid availability zone",
+			optionsJson: `{
+				"Tenant Id": "tenant-id-123",
+				"Client Id": "client-id-123",
+				"Client Secret": "client-secret-123",
+				"Subscription Id": "subscription-id-123",
+				"Availability Zone": "eastus-1"
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "Unsupported auth method",
+			optionsJson: `{
+				"Auth Method": "Password",
+				"Subscription Id": "subscription-id-123"
+			}`,
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -146,3 +721,56 @@ func TestParseTargetOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestSecurityFeatureDefaults(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           TargetOptions
+		wantSecureBoot bool
+		wantVTPM       bool
+	}{
+		{
+			name:           "Unset",
+			opts:           TargetOptions{},
+			wantSecureBoot: true,
+			wantVTPM:       true,
+		},
+		{
+			name:           "Enabled",
+			opts:           TargetOptions{SecureBoot: boolPtr(true), VTPM: boolPtr(true)},
+			wantSecureBoot: true,
+			wantVTPM:       true,
+		},
+		{
+			name:           "Disabled",
+			opts:           TargetOptions{SecureBoot: boolPtr(false), VTPM: boolPtr(false)},
+			wantSecureBoot: false,
+			wantVTPM:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.IsSecureBootEnabled(); got != tt.wantSecureBoot {
+				t.Errorf("IsSecureBootEnabled() = %v, want %v", got, tt.wantSecureBoot)
+			}
+			if got := tt.opts.IsVTPMEnabled(); got != tt.wantVTPM {
+				t.Errorf("IsVTPMEnabled() = %v, want %v", got, tt.wantVTPM)
+			}
+		})
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestVMSizeList(t *testing.T) {
+	opts := &TargetOptions{VMSize: "Standard_D2s_v5, Standard_D2as_v5,,Standard_B2s "}
+
+	got := opts.VMSizeList()
+	want := []string{"Standard_D2s_v5", "Standard_D2as_v5", "Standard_B2s"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("VMSizeList() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,253 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	ArchitectureX64   = "x64"
+	ArchitectureArm64 = "Arm64"
+)
+
+// VMSizeInfo describes the hardware of an Azure virtual machine size.
+type VMSizeInfo struct {
+	Name           string
+	VCPUs          int
+	MemoryGB       float64
+	Architecture   string
+	PremiumStorage bool
+	// HourlyPrice is the approximate pay-as-you-go Linux price in USD in US regions.
+	// Zero means the price is unknown.
+	HourlyPrice float64
+}
+
+// vmSizeCatalogue lists commonly used VM sizes with their hardware and approximate price.
+var vmSizeCatalogue = []VMSizeInfo{
+	{"Standard_B1s", 1, 1, ArchitectureX64, true, 0.0104},
+	{"Standard_B1ms", 1, 2, ArchitectureX64, true, 0.0207},
+	{"Standard_B2s", 2, 4, ArchitectureX64, true, 0.0416},
+	{"Standard_B2ms", 2, 8, ArchitectureX64, true, 0.0832},
+	{"Standard_B4ms", 4, 16, ArchitectureX64, true, 0.166},
+	{"Standard_B8ms", 8, 32, ArchitectureX64, true, 0.333},
+	{"Standard_B12ms", 12, 48, ArchitectureX64, true, 0.499},
+	{"Standard_B16ms", 16, 64, ArchitectureX64, true, 0.666},
+	{"Standard_B20ms", 20, 80, ArchitectureX64, true, 0.832},
+	{"Standard_B2s_v2", 2, 8, ArchitectureX64, true, 0.0832},
+	{"Standard_B4s_v2", 4, 16, ArchitectureX64, true, 0.166},
+	{"Standard_B8s_v2", 8, 32, ArchitectureX64, true, 0.333},
+	{"Standard_B16s_v2", 16, 64, ArchitectureX64, true, 0.666},
+	{"Standard_B2as_v2", 2, 8, ArchitectureX64, true, 0.0752},
+	{"Standard_B4as_v2", 4, 16, ArchitectureX64, true, 0.150},
+	{"Standard_B2ps_v2", 2, 8, ArchitectureArm64, true, 0.0672},
+	{"Standard_B4ps_v2", 4, 16, ArchitectureArm64, true, 0.134},
+	{"Standard_A1_v2", 1, 2, ArchitectureX64, false, 0.043},
+	{"Standard_A2_v2", 2, 4, ArchitectureX64, false, 0.091},
+	{"Standard_A4_v2", 4, 8, ArchitectureX64, false, 0.191},
+	{"Standard_A8_v2", 8, 16, ArchitectureX64, false, 0.400},
+	{"Standard_D2_v5", 2, 8, ArchitectureX64, false, 0.096},
+	{"Standard_D4_v5", 4, 16, ArchitectureX64, false, 0.192},
+	{"Standard_D8_v5", 8, 32, ArchitectureX64, false, 0.384},
+	{"Standard_D16_v5", 16, 64, ArchitectureX64, false, 0.768},
+	{"Standard_D2s_v3", 2, 8, ArchitectureX64, true, 0.096},
+	{"Standard_D4s_v3", 4, 16, ArchitectureX64, true, 0.192},
+	{"Standard_D8s_v3", 8, 32, ArchitectureX64, true, 0.384},
+	{"Standard_D16s_v3", 16, 64, ArchitectureX64, true, 0.768},
+	{"Standard_D2s_v5", 2, 8, ArchitectureX64, true, 0.096},
+	{"Standard_D4s_v5", 4, 16, ArchitectureX64, true, 0.192},
+	{"Standard_D8s_v5", 8, 32, ArchitectureX64, true, 0.384},
+	{"Standard_D16s_v5", 16, 64, ArchitectureX64, true, 0.768},
+	{"Standard_D32s_v5", 32, 128, ArchitectureX64, true, 1.536},
+	{"Standard_D48s_v5", 48, 192, ArchitectureX64, true, 2.304},
+	{"Standard_D64s_v5", 64, 256, ArchitectureX64, true, 3.072},
+	{"Standard_D96s_v5", 96, 384, ArchitectureX64, true, 4.608},
+	{"Standard_D2as_v5", 2, 8, ArchitectureX64, true, 0.086},
+	{"Standard_D4as_v5", 4, 16, ArchitectureX64, true, 0.172},
+	{"Standard_D8as_v5", 8, 32, ArchitectureX64, true, 0.344},
+	{"Standard_D16as_v5", 16, 64, ArchitectureX64, true, 0.688},
+	{"Standard_D32as_v5", 32, 128, ArchitectureX64, true, 1.376},
+	{"Standard_D2ps_v5", 2, 8, ArchitectureArm64, true, 0.077},
+	{"Standard_D4ps_v5", 4, 16, ArchitectureArm64, true, 0.154},
+	{"Standard_D8ps_v5", 8, 32, ArchitectureArm64, true, 0.308},
+	{"Standard_D16ps_v5", 16, 64, ArchitectureArm64, true, 0.616},
+	{"Standard_E2s_v5", 2, 16, ArchitectureX64, true, 0.126},
+	{"Standard_E4s_v5", 4, 32, ArchitectureX64, true, 0.252},
+	{"Standard_E8s_v5", 8, 64, ArchitectureX64, true, 0.504},
+	{"Standard_E16s_v5", 16, 128, ArchitectureX64, true, 1.008},
+	{"Standard_E32s_v5", 32, 256, ArchitectureX64, true, 2.016},
+	{"Standard_F2s_v2", 2, 4, ArchitectureX64, true, 0.0846},
+	{"Standard_F4s_v2", 4, 8, ArchitectureX64, true, 0.169},
+	{"Standard_F8s_v2", 8, 16, ArchitectureX64, true, 0.338},
+	{"Standard_F16s_v2", 16, 32, ArchitectureX64, true, 0.677},
+	{"Standard_F32s_v2", 32, 64, ArchitectureX64, true, 1.353},
+	{"Standard_L8s_v3", 8, 64, ArchitectureX64, true, 0.624},
+	{"Standard_NC24ads_A100_v4", 24, 220, ArchitectureX64, true, 3.673},
+}
+
+// recommendedVMSizes are described in the VM Size manifest property.
+var recommendedVMSizes = []string{"Standard_B2s", "Standard_B4ms", "Standard_D4s_v5", "Standard_D8s_v5", "Standard_D16s_v5", "Standard_E8s_v5"}
+
+// vmSizeNamePattern matches the vCPU count and the additive feature letters of a size name,
+// e.g. "Standard_D4as_v5" or "Standard_E16-4ds_v4".
+var vmSizeNamePattern = regexp.MustCompile(`^[A-Za-z]+_[A-Z]+(\d+)(?:-\d+)?([a-z]*)`)
+
+// GetVMSizeInfo returns the catalogue entry of the VM size. Sizes missing from the
+// catalogue are described from their name, without memory and price.
+func GetVMSizeInfo(name string) (VMSizeInfo, bool) {
+	for _, info := range vmSizeCatalogue {
+		if strings.EqualFold(info.Name, name) {
+			return info, true
+		}
+	}
+
+	match := vmSizeNamePattern.FindStringSubmatch(name)
+	if match == nil {
+		return VMSizeInfo{Name: name}, false
+	}
+
+	vCPUs, _ := strconv.Atoi(match[1])
+	info := VMSizeInfo{
+		Name:           name,
+		VCPUs:          vCPUs,
+		Architecture:   ArchitectureX64,
+		PremiumStorage: strings.Contains(match[2], "s"),
+	}
+	if strings.Contains(match[2], "p") {
+		info.Architecture = ArchitectureArm64
+	}
+
+	return info, false
+}
+
+// withCatalogueVMSizes returns the VM size names extended with the catalogued sizes.
+func withCatalogueVMSizes(names []string) []string {
+	merged := []string{}
+	seen := map[string]bool{}
+	for _, info := range vmSizeCatalogue {
+		merged = append(merged, info.Name)
+		seen[strings.ToLower(info.Name)] = true
+	}
+
+	for _, name := range names {
+		if !seen[strings.ToLower(name)] {
+			merged = append(merged, name)
+			seen[strings.ToLower(name)] = true
+		}
+	}
+
+	return merged
+}
+
+// FormatVMSizeInfo returns a short human-readable description of a VM size.
+func FormatVMSizeInfo(info VMSizeInfo) string {
+	description := fmt.Sprintf("%s: %d vCPU", info.Name, info.VCPUs)
+	if info.MemoryGB > 0 {
+		description += fmt.Sprintf(", %g GiB", info.MemoryGB)
+	}
+	if info.Architecture == ArchitectureArm64 {
+		description += ", Arm64"
+	}
+	if info.PremiumStorage {
+		description += ", premium storage"
+	}
+	if info.HourlyPrice > 0 {
+		description += fmt.Sprintf(", ~$%.3f/h", info.HourlyPrice)
+	}
+
+	return description
+}
+
+// SortVMSizes orders VM size names from the smallest to the largest. Catalogued sizes
+// come first, ordered by vCPUs, memory and price, followed by the remaining sizes by name.
+func SortVMSizes(names []string) []string {
+	sorted := make([]string, len(names))
+	copy(sorted, names)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		infoI, knownI := GetVMSizeInfo(sorted[i])
+		infoJ, knownJ := GetVMSizeInfo(sorted[j])
+
+		if knownI != knownJ {
+			return knownI
+		}
+		if !knownI {
+			return sorted[i] < sorted[j]
+		}
+		if infoI.VCPUs != infoJ.VCPUs {
+			return infoI.VCPUs < infoJ.VCPUs
+		}
+		if infoI.MemoryGB != infoJ.MemoryGB {
+			return infoI.MemoryGB < infoJ.MemoryGB
+		}
+		return infoI.HourlyPrice < infoJ.HourlyPrice
+	})
+
+	return sorted
+}
+
+// NearestVMSizes returns up to count candidates closest to the target size by vCPUs and
+// memory. Sizes with a different architecture are ranked last, and ties are broken by price.
+func NearestVMSizes(target VMSizeInfo, candidates []VMSizeInfo, count int) []string {
+	type rankedSize struct {
+		info     VMSizeInfo
+		distance float64
+	}
+
+	ranked := []rankedSize{}
+	for _, candidate := range candidates {
+		if strings.EqualFold(candidate.Name, target.Name) || candidate.VCPUs == 0 {
+			continue
+		}
+
+		distance := 2 * math.Abs(math.Log2(float64(candidate.VCPUs)/float64(max(target.VCPUs, 1))))
+		if target.MemoryGB > 0 && candidate.MemoryGB > 0 {
+			distance += math.Abs(math.Log2(candidate.MemoryGB / target.MemoryGB))
+		}
+		if target.Architecture != "" && candidate.Architecture != target.Architecture {
+			distance += 100
+		}
+		if target.PremiumStorage && !candidate.PremiumStorage {
+			distance += 1
+		}
+
+		ranked = append(ranked, rankedSize{info: candidate, distance: distance})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].distance != ranked[j].distance {
+			return ranked[i].distance < ranked[j].distance
+		}
+		if ranked[i].info.HourlyPrice != ranked[j].info.HourlyPrice {
+			return ranked[i].info.HourlyPrice < ranked[j].info.HourlyPrice
+		}
+		return ranked[i].info.Name < ranked[j].info.Name
+	})
+
+	nearest := []string{}
+	for i := 0; i < len(ranked) && i < count; i++ {
+		nearest = append(nearest, ranked[i].info.Name)
+	}
+
+	return nearest
+}
+
+// getVMSizeDescription returns the description of the VM Size manifest property.
+func getVMSizeDescription() string {
+	description := "The size of the Azure machine. Default is Standard_B2s.\n" +
+		"The suggested sizes are those offered in " + DefaultRegion + ". Sizes are checked against the chosen region before\n" +
+		"the target is created, and the nearest sizes available there are listed if a size is not offered.\n" +
+		"A comma-separated list, e.g. Standard_D2s_v5,Standard_D2as_v5, is tried in order when a size has no capacity or quota.\n" +
+		"Common sizes (approximate pay-as-you-go Linux price in US regions):\n"
+
+	for _, name := range recommendedVMSizes {
+		info, _ := GetVMSizeInfo(name)
+		description += "  " + FormatVMSizeInfo(info) + "\n"
+	}
+
+	return description + "List of available sizes:\nhttps://learn.microsoft.com/en-us/azure/virtual-machines/sizes/overview\n" +
+		"List of available sizes per location can be retrieved using the command:\naz vm list-sizes --location <your-region> --output table"
+}
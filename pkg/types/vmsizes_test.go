@@ -0,0 +1,71 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetVMSizeInfo(t *testing.T) {
+	tests := []struct {
+		name   string
+		vmSize string
+		want   VMSizeInfo
+		known  bool
+	}{
+		{
+			name:   "catalogued size",
+			vmSize: "standard_d4s_v5",
+			want:   VMSizeInfo{"Standard_D4s_v5", 4, 16, ArchitectureX64, true, 0.192},
+			known:  true,
+		},
+		{
+			name:   "constrained vCPU size",
+			vmSize: "Standard_E16-4as_v4",
+			want:   VMSizeInfo{Name: "Standard_E16-4as_v4", VCPUs: 16, Architecture: ArchitectureX64, PremiumStorage: true},
+		},
+		{
+			name:   "arm size",
+			vmSize: "Standard_D32pds_v5",
+			want:   VMSizeInfo{Name: "Standard_D32pds_v5", VCPUs: 32, Architecture: ArchitectureArm64, PremiumStorage: true},
+		},
+		{
+			name:   "unparsable size",
+			vmSize: "custom",
+			want:   VMSizeInfo{Name: "custom"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, known := GetVMSizeInfo(tt.vmSize)
+			if known != tt.known || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetVMSizeInfo() = %v, %v, want %v, %v", got, known, tt.want, tt.known)
+			}
+		})
+	}
+}
+
+func TestSortVMSizes(t *testing.T) {
+	got := SortVMSizes([]string{"Standard_X1", "Standard_D4s_v5", "Standard_B2s", "Standard_B2ms", "Standard_B1s", "Standard_A0"})
+	want := []string{"Standard_B1s", "Standard_B2s", "Standard_B2ms", "Standard_D4s_v5", "Standard_A0", "Standard_X1"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SortVMSizes() = %v, want %v", got, want)
+	}
+}
+
+func TestNearestVMSizes(t *testing.T) {
+	candidates := []VMSizeInfo{}
+	for _, name := range []string{"Standard_B2s", "Standard_D2s_v5", "Standard_D4as_v5", "Standard_D4ps_v5", "Standard_D8s_v5", "Standard_D64s_v5"} {
+		info, _ := GetVMSizeInfo(name)
+		candidates = append(candidates, info)
+	}
+
+	target, _ := GetVMSizeInfo("Standard_D4s_v5")
+	got := NearestVMSizes(target, candidates, 3)
+	want := []string{"Standard_D4as_v5", "Standard_D2s_v5", "Standard_D8s_v5"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NearestVMSizes() = %v, want %v", got, want)
+	}
+}